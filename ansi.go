@@ -6,6 +6,8 @@ import (
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 // The states of the ANSI escape code parser.
@@ -268,3 +270,44 @@ func TranslateANSI(text string) string {
 	writer.Write([]byte(text))
 	return buffer.String()
 }
+
+// styleToANSI returns an ANSI escape sequence which resets all attributes and
+// then applies the colors and attributes of the provided style.
+func styleToANSI(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+
+	params := []string{"0"}
+	for _, a := range []struct {
+		mask tcell.AttrMask
+		code string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrItalic, "3"},
+		{tcell.AttrUnderline, "4"},
+		{tcell.AttrBlink, "5"},
+		{tcell.AttrReverse, "7"},
+		{tcell.AttrStrikeThrough, "9"},
+	} {
+		if attrs&a.mask != 0 {
+			params = append(params, a.code)
+		}
+	}
+	params = append(params, colorToANSI(fg, 38)...)
+	params = append(params, colorToANSI(bg, 48)...)
+
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// colorToANSI returns the SGR parameters which set the provided color. The
+// base is 38 for foreground colors and 48 for background colors.
+func colorToANSI(color tcell.Color, base int) []string {
+	if color == tcell.ColorDefault || !color.Valid() {
+		return nil
+	}
+	if color.IsRGB() {
+		r, g, b := color.RGB()
+		return []string{strconv.Itoa(base), "2", strconv.Itoa(int(r)), strconv.Itoa(int(g)), strconv.Itoa(int(b))}
+	}
+	return []string{strconv.Itoa(base), "5", strconv.Itoa(int(color - tcell.ColorValid))}
+}
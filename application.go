@@ -1,10 +1,16 @@
 package cview
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
 	"sync"
 	"time"
 
+	"code.rocketnine.space/tslocum/cbind"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -14,6 +20,10 @@ const (
 
 	// The minimum duration between resize event callbacks.
 	resizeEventThrottle = 50 * time.Millisecond
+
+	// The maximum duration between characters which are coalesced into a
+	// paste in terminals without bracketed paste support.
+	pasteCoalesceInterval = 5 * time.Millisecond
 )
 
 // Application represents the top node of an application.
@@ -34,12 +44,19 @@ type Application struct {
 	// Fini(), to set a new screen (or nil to stop the application).
 	screen tcell.Screen
 
+	// Whether or not the screen provided with SetScreen before running the
+	// application still needs to be initialized.
+	screenUninitialized bool
+
 	// The size of the application's screen.
 	width, height int
 
 	// The primitive which currently has the keyboard focus.
 	focus Primitive
 
+	// Previously focused primitives saved with PushFocus.
+	focusStack []Primitive
+
 	// The root primitive to be seen on the screen.
 	root Primitive
 
@@ -49,6 +66,11 @@ type Application struct {
 	// Whether or not to enable bracketed paste mode.
 	enableBracketedPaste bool
 
+	// Whether or not a bracketed paste is in progress, and the key events
+	// received during the paste.
+	pasting   bool
+	pasteKeys []*tcell.EventKey
+
 	// Whether or not to enable mouse events.
 	enableMouse bool
 
@@ -57,12 +79,24 @@ type Application struct {
 	// be forwarded).
 	inputCapture func(event *tcell.EventKey) *tcell.EventKey
 
+	// Application-wide shortcuts, indexed by their encoded key combination.
+	shortcuts map[string]func() bool
+
 	// Time a resize event was last processed.
 	lastResize time.Time
 
 	// Timer limiting how quickly resize events are processed.
 	throttleResize *time.Timer
 
+	// The maximum number of times the screen is drawn per second (0 = unlimited).
+	maxFPS int
+
+	// Time the screen was last drawn.
+	lastDraw time.Time
+
+	// Timer which draws the screen after draws were skipped due to maxFPS.
+	throttleDraw *time.Timer
+
 	// An optional callback function which is invoked when the application's
 	// window is initialized, and when the application's window size changes.
 	// After invoking this callback the screen is cleared and the application
@@ -77,6 +111,14 @@ type Application struct {
 	// focus changes.
 	afterFocus func(p Primitive)
 
+	// An optional writer which receives a description of the focused
+	// primitive whenever the focus changes.
+	accessibilityWriter io.Writer
+
+	// An optional callback function which is invoked before the application
+	// stops.
+	beforeStop func() bool
+
 	// An optional callback function which is invoked just before the root
 	// primitive is drawn.
 	beforeDraw func(screen tcell.Screen) bool
@@ -85,6 +127,11 @@ type Application struct {
 	// was drawn.
 	afterDraw func(screen tcell.Screen)
 
+	// The number of PauseInput calls which were not followed by a call to
+	// ResumeInput yet, and the times when input was last paused and resumed.
+	inputPaused                   int
+	inputPausedAt, inputResumedAt time.Time
+
 	// Used to send screen events from separate goroutine to main event loop
 	events chan tcell.Event
 
@@ -110,6 +157,7 @@ type Application struct {
 	lastMouseX, lastMouseY  int              // The last position of the mouse.
 	mouseDownX, mouseDownY  int              // The position of the mouse when its button was last pressed.
 	lastMouseClick          time.Time        // The time when a mouse button was last clicked.
+	lastClickX, lastClickY  int              // The position of the mouse when a mouse button was last clicked.
 	lastMouseButtons        tcell.ButtonMask // The last mouse button state.
 
 	sync.RWMutex
@@ -193,12 +241,85 @@ func (a *Application) SetDoubleClickInterval(interval time.Duration) {
 	a.doubleClickInterval = interval
 }
 
+// RegisterShortcut registers an application-wide shortcut. The handler is
+// called when the key combination is pressed, regardless of which primitive
+// has focus. Shortcuts are handled after the input capture function and before
+// the focused primitive's input handler. Return true from the handler to
+// consume the event, or false to pass it on to the focused primitive.
+//
+// Registering a shortcut replaces any handler previously registered for the
+// same key combination. Shortcuts for Ctrl+C and rune keys are not supported.
+func (a *Application) RegisterShortcut(key tcell.Key, mod tcell.ModMask, handler func() bool) {
+	enc, err := cbind.Encode(mod, key, 0)
+	if err != nil {
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	shortcuts := make(map[string]func() bool, len(a.shortcuts)+1)
+	for k, v := range a.shortcuts {
+		shortcuts[k] = v
+	}
+	shortcuts[enc] = handler
+	a.shortcuts = shortcuts
+}
+
+// UnregisterShortcut removes an application-wide shortcut registered with
+// RegisterShortcut.
+func (a *Application) UnregisterShortcut(key tcell.Key, mod tcell.ModMask) {
+	enc, err := cbind.Encode(mod, key, 0)
+	if err != nil {
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	shortcuts := make(map[string]func() bool, len(a.shortcuts))
+	for k, v := range a.shortcuts {
+		if k != enc {
+			shortcuts[k] = v
+		}
+	}
+	a.shortcuts = shortcuts
+}
+
+// SetMaxFPS limits how many times per second the entire screen is drawn.
+// Requests to draw the screen which arrive too quickly are coalesced into a
+// single draw which happens as soon as the limit permits. Set to 0 to disable
+// the limit (the default).
+func (a *Application) SetMaxFPS(fps int) {
+	a.Lock()
+	defer a.Unlock()
+
+	if fps < 0 {
+		fps = 0
+	}
+	a.maxFPS = fps
+}
+
 // SetScreen allows you to provide your own tcell.Screen object. For most
 // applications, this is not needed and you should be familiar with
 // tcell.Screen when using this function.
 //
 // This function is typically called before the first call to Run(). Init() need
-// not be called on the screen.
+// not be called on the screen. Run() initializes it if this hasn't been done
+// yet and uses it instead of the terminal.
+//
+// This allows running the application headless with a tcell.SimulationScreen,
+// e.g. in tests. Simulation screens are always initialized by Run(), keeping
+// the size set with SetSize(), so events should be injected once the
+// application is running. The rendered cells may then be read back with the
+// screen's GetContents() or with Screenshot():
+//
+//	screen := tcell.NewSimulationScreen("UTF-8")
+//	screen.SetSize(80, 24)
+//	app.SetScreen(screen)
+//	go app.Run()
+//	app.QueueUpdateSync(func() {}) // Wait for the application to run.
+//	screen.InjectKey(tcell.KeyRune, 'a', tcell.ModNone)
 func (a *Application) SetScreen(screen tcell.Screen) {
 	if screen == nil {
 		return // Invalid input. Do nothing.
@@ -208,6 +329,9 @@ func (a *Application) SetScreen(screen tcell.Screen) {
 	if a.screen == nil {
 		// Run() has not been called yet.
 		a.screen = screen
+		_, simulation := screen.(tcell.SimulationScreen)
+		width, height := screen.Size()
+		a.screenUninitialized = simulation || width == 0 && height == 0
 		a.Unlock()
 		return
 	}
@@ -228,6 +352,61 @@ func (a *Application) GetScreen() tcell.Screen {
 	return a.screen
 }
 
+// Screenshot returns the current content of the screen as plain text. Each row
+// of the screen is terminated by a newline. An empty string is returned when
+// the application has no screen.
+func (a *Application) Screenshot() string {
+	return a.screenshot(false)
+}
+
+// ScreenshotANSI returns the current content of the screen as text containing
+// ANSI escape sequences which preserve the colors and attributes of each cell.
+// Each row of the screen is terminated by a newline. An empty string is
+// returned when the application has no screen.
+func (a *Application) ScreenshotANSI() string {
+	return a.screenshot(true)
+}
+
+// screenshot returns the current content of the screen, optionally including
+// ANSI escape sequences.
+func (a *Application) screenshot(ansi bool) string {
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.screen == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	width, height := a.screen.Size()
+	for y := 0; y < height; y++ {
+		lastStyle := tcell.StyleDefault
+		for x := 0; x < width; {
+			mainc, combc, style, w := a.screen.GetContent(x, y)
+			if ansi && style != lastStyle {
+				b.WriteString(styleToANSI(style))
+				lastStyle = style
+			}
+			if mainc == 0 {
+				mainc = ' '
+			}
+			b.WriteRune(mainc)
+			for _, r := range combc {
+				b.WriteRune(r)
+			}
+			if w < 1 {
+				w = 1
+			}
+			x += w
+		}
+		if ansi && lastStyle != tcell.StyleDefault {
+			b.WriteString(styleToANSI(tcell.StyleDefault))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
 // GetScreenSize returns the size of the application's screen. These values are
 // only available after calling Init or Run.
 func (a *Application) GetScreenSize() (width, height int) {
@@ -247,7 +426,11 @@ func (a *Application) Init() error {
 
 func (a *Application) init() error {
 	if a.screen != nil {
-		return nil
+		if !a.screenUninitialized {
+			return nil
+		}
+		a.screenUninitialized = false
+		return a.initScreen(a.screen)
 	}
 
 	var err error
@@ -255,15 +438,25 @@ func (a *Application) init() error {
 	if err != nil {
 		return err
 	}
-	if err = a.screen.Init(); err != nil {
+	return a.initScreen(a.screen)
+}
+
+// initScreen initializes the provided screen. Simulation screens keep the size
+// which was set before initializing them. The caller must hold the lock.
+func (a *Application) initScreen(screen tcell.Screen) error {
+	width, height := screen.Size()
+	if err := screen.Init(); err != nil {
 		return err
 	}
-	a.width, a.height = a.screen.Size()
+	if simulation, ok := screen.(tcell.SimulationScreen); ok && width > 0 && height > 0 {
+		simulation.SetSize(width, height)
+	}
+	a.width, a.height = screen.Size()
 	if a.enableBracketedPaste {
-		a.screen.EnablePaste()
+		screen.EnablePaste()
 	}
 	if a.enableMouse {
-		a.screen.EnableMouse()
+		screen.EnableMouse()
 	}
 	return nil
 }
@@ -339,35 +532,63 @@ func (a *Application) Run() error {
 				return
 			}
 
-			// We have a new screen. Keep going.
+			// We have a new screen. Initialize and draw it.
 			a.Lock()
 			a.screen = screen
+			err := a.initScreen(screen)
 			a.Unlock()
-
-			// Initialize and draw this screen.
-			if err := screen.Init(); err != nil {
+			if err != nil {
 				panic(err)
 			}
-			if a.enableBracketedPaste {
-				screen.EnablePaste()
-			}
-			if a.enableMouse {
-				screen.EnableMouse()
-			}
 
 			a.draw()
 		}
 	}()
 
-	handle := func(event interface{}) {
+	var handle func(event interface{})
+	handle = func(event interface{}) {
 		a.RLock()
 		p := a.focus
 		inputCapture := a.inputCapture
+		shortcuts := a.shortcuts
 		screen := a.screen
 		a.RUnlock()
 
+		if event, ok := event.(tcell.Event); ok && a.inputDiscarded(event) {
+			if _, ok := event.(*tcell.EventPaste); ok {
+				// Drop the entire paste instead of collecting keys forever.
+				a.pasting = false
+				a.pasteKeys = nil
+			}
+			return
+		}
+
 		switch event := event.(type) {
+		case *tcell.EventPaste:
+			if event.Start() {
+				a.pasting = true
+				a.pasteKeys = nil
+				return
+			}
+			a.pasting = false
+			keys := a.pasteKeys
+			a.pasteKeys = nil
+			a.paste(p, inputCapture, keys, handle)
+			a.draw()
+		case *pasteEvent:
+			if a.pasting {
+				a.pasteKeys = append(a.pasteKeys, event.keys...)
+				return
+			}
+			a.paste(p, inputCapture, event.keys, handle)
+			a.draw()
 		case *tcell.EventKey:
+			// Collect keys of bracketed pastes.
+			if a.pasting {
+				a.pasteKeys = append(a.pasteKeys, event)
+				return
+			}
+
 			// Intercept keys.
 			if inputCapture != nil {
 				event = inputCapture(event)
@@ -383,6 +604,26 @@ func (a *Application) Run() error {
 				return
 			}
 
+			// Handle application-wide shortcuts.
+			if len(shortcuts) > 0 && event.Key() != tcell.KeyRune {
+				enc, err := cbind.Encode(event.Modifiers(), event.Key(), 0)
+				if handler := shortcuts[enc]; err == nil && handler != nil && handler() {
+					a.draw()
+					return
+				}
+			}
+
+			// Let the containers of the focused primitive handle the event.
+			a.RLock()
+			root := a.root
+			a.RUnlock()
+			if interceptKey(event, func(p Primitive) {
+				a.SetFocus(p)
+			}, root) {
+				a.draw()
+				return
+			}
+
 			// Pass other key events to the currently focused primitive.
 			if p != nil {
 				if handler := p.InputHandler(); handler != nil {
@@ -420,11 +661,15 @@ func (a *Application) Run() error {
 			}
 
 			screen.Clear()
-			a.width, a.height = event.Size()
+			width, height := event.Size()
+			a.Lock()
+			a.width, a.height = width, height
+			afterResize := a.afterResize
+			a.Unlock()
 
 			// Call afterResize handler if there is one.
-			if a.afterResize != nil {
-				a.afterResize(a.width, a.height)
+			if afterResize != nil {
+				afterResize(width, height)
 			}
 
 			a.draw()
@@ -463,6 +708,7 @@ func (a *Application) Run() error {
 	}()
 
 	// Start screen event loop.
+	var pending tcell.Event
 	for {
 		a.Lock()
 		screen := a.screen
@@ -473,11 +719,37 @@ func (a *Application) Run() error {
 		}
 
 		// Wait for next event.
-		event := screen.PollEvent()
+		var event tcell.Event
+		if pending != nil {
+			event, pending = pending, nil
+		} else {
+			event = screen.PollEvent()
+		}
 		if event == nil {
 			break
 		}
 
+		// Coalesce characters which are received in quick succession, e.g.
+		// when text is pasted in terminals without bracketed paste support.
+		if key, ok := event.(*tcell.EventKey); ok && isPasteKey(key) {
+			keys := []*tcell.EventKey{key}
+			for screen.HasPendingEvent() {
+				next := screen.PollEvent()
+				nextKey, ok := next.(*tcell.EventKey)
+				if !ok || !isPasteKey(nextKey) || nextKey.When().Sub(key.When()) > pasteCoalesceInterval {
+					pending = next
+					break
+				}
+				keys = append(keys, nextKey)
+				key = nextKey
+			}
+			if len(keys) > 1 {
+				paste := &pasteEvent{keys: keys}
+				paste.SetEventNow()
+				event = paste
+			}
+		}
+
 		semaphore.Lock()
 		handle(event)
 		semaphore.Unlock()
@@ -492,6 +764,69 @@ func (a *Application) Run() error {
 	return nil
 }
 
+// pasteEvent is a paste of characters which were received in quick
+// succession.
+type pasteEvent struct {
+	tcell.EventTime
+	keys []*tcell.EventKey
+}
+
+// isPasteKey returns whether or not the provided key event may be part of a
+// paste of characters which were received in quick succession.
+func isPasteKey(event *tcell.EventKey) bool {
+	return event.Key() == tcell.KeyRune && event.Modifiers()&(tcell.ModAlt|tcell.ModCtrl|tcell.ModMeta) == 0
+}
+
+// paste passes the text of the provided key events to the focused primitive
+// when it is a PastePrimitive, after passing them through the input capture
+// functions of the application and of the primitive. Otherwise, the key
+// events are handled one by one.
+func (a *Application) paste(p Primitive, inputCapture func(event *tcell.EventKey) *tcell.EventKey, keys []*tcell.EventKey, handle func(event interface{})) {
+	pastePrimitive, ok := p.(PastePrimitive)
+	if !ok {
+		for _, key := range keys {
+			handle(key)
+		}
+		return
+	}
+
+	var primitiveCapture func(event *tcell.EventKey) *tcell.EventKey
+	if p, ok := p.(interface {
+		GetInputCapture() func(event *tcell.EventKey) *tcell.EventKey
+	}); ok {
+		primitiveCapture = p.GetInputCapture()
+	}
+
+	var b strings.Builder
+	for _, key := range keys {
+		if inputCapture != nil {
+			key = inputCapture(key)
+			if key == nil {
+				continue
+			}
+		}
+		if primitiveCapture != nil {
+			key = primitiveCapture(key)
+			if key == nil {
+				continue
+			}
+		}
+
+		switch key.Key() {
+		case tcell.KeyRune:
+			b.WriteRune(key.Rune())
+		case tcell.KeyEnter:
+			b.WriteRune('\n')
+		case tcell.KeyTab:
+			b.WriteRune('\t')
+		}
+	}
+
+	pastePrimitive.PasteHandler()(b.String(), func(p Primitive) {
+		a.SetFocus(p)
+	})
+}
+
 // fireMouseActions analyzes the provided mouse event, derives mouse actions
 // from it and then forwards them to the corresponding primitives.
 func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMouseDownAction bool) {
@@ -563,9 +898,10 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 			} else {
 				fire(buttonEvent.up)
 				if !clickMoved {
-					if a.doubleClickInterval == 0 || a.lastMouseClick.Add(a.doubleClickInterval).Before(time.Now()) {
+					if a.doubleClickInterval == 0 || a.lastMouseClick.Add(a.doubleClickInterval).Before(time.Now()) || x != a.lastClickX || y != a.lastClickY {
 						fire(buttonEvent.click)
 						a.lastMouseClick = time.Now()
+						a.lastClickX, a.lastClickY = x, y
 					} else {
 						fire(buttonEvent.dclick)
 						a.lastMouseClick = time.Time{} // reset
@@ -575,6 +911,16 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 		}
 	}
 
+	// Scrolling vertically while holding Shift scrolls horizontally.
+	if event.Modifiers()&tcell.ModShift != 0 {
+		if buttons&tcell.WheelUp != 0 {
+			buttons = buttons&^tcell.WheelUp | tcell.WheelLeft
+		}
+		if buttons&tcell.WheelDown != 0 {
+			buttons = buttons&^tcell.WheelDown | tcell.WheelRight
+		}
+	}
+
 	for _, wheelEvent := range []struct {
 		button tcell.ButtonMask
 		action MouseAction
@@ -591,8 +937,17 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 	return consumed, isMouseDownAction
 }
 
-// Stop stops the application, causing Run() to return.
+// Stop stops the application, causing Run() to return. When a handler has been
+// installed via SetBeforeStopFunc, it is called first and may cancel stopping.
 func (a *Application) Stop() {
+	a.RLock()
+	beforeStop := a.beforeStop
+	a.RUnlock()
+
+	if beforeStop != nil && !beforeStop() {
+		return
+	}
+
 	a.Lock()
 	defer a.Unlock()
 
@@ -642,6 +997,42 @@ func (a *Application) Suspend(f func()) bool {
 	return true
 }
 
+// RunExternal suspends the application, runs the provided command and resumes
+// the application once the command has exited. The screen is redrawn entirely
+// afterwards. When the standard input, output or error of the command are not
+// set, they are attached to those of the application's process. The error
+// returned by the command is returned.
+//
+// This function may be called from an input handler, e.g. to open an editor.
+func (a *Application) RunExternal(cmd *exec.Cmd) error {
+	if cmd.Stdin == nil {
+		cmd.Stdin = os.Stdin
+	}
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+
+	var err error
+	if !a.Suspend(func() {
+		err = cmd.Run()
+	}) {
+		return errors.New("failed to run external command: application is not running")
+	}
+
+	a.RLock()
+	screen := a.screen
+	a.RUnlock()
+	if screen != nil {
+		screen.Sync()
+	}
+	a.draw()
+
+	return err
+}
+
 // Draw draws the provided primitives on the screen, or when no primitives are
 // provided, draws the application's root primitive (i.e. the entire screen).
 //
@@ -686,6 +1077,28 @@ func (a *Application) draw() {
 		return
 	}
 
+	// Throttle draws.
+	if a.maxFPS > 0 {
+		wait := time.Second/time.Duration(a.maxFPS) - time.Since(a.lastDraw)
+		if wait > 0 {
+			if a.throttleDraw == nil {
+				a.throttleDraw = time.AfterFunc(wait, func() {
+					a.QueueUpdate(func() {
+						a.Lock()
+						a.throttleDraw = nil
+						a.lastDraw = time.Time{}
+						a.Unlock()
+
+						a.draw()
+					})
+				})
+			}
+			a.Unlock()
+			return
+		}
+		a.lastDraw = time.Now()
+	}
+
 	// Resize if requested.
 	if fullscreen {
 		root.SetRect(0, 0, a.width, a.height)
@@ -789,10 +1202,16 @@ func (a *Application) ResizeToFullScreen(p Primitive) {
 	p.SetRect(0, 0, width, height)
 }
 
-// SetAfterResizeFunc installs a callback function which is invoked when the
-// application's window is initialized, and when the application's window size
-// changes. After invoking this callback the screen is cleared and the
-// application is drawn.
+// SetAfterResizeFunc installs a callback function which is invoked with the
+// new dimensions when the application's window is initialized, and when the
+// application's window size changes. The screen is cleared before invoking
+// this callback and the application is drawn afterwards.
+//
+// The callback is invoked once for each resize event which is processed.
+// Resize events received in quick succession are throttled so that only the
+// last one is processed. As the callback runs in the event loop, it may
+// safely modify primitives. It may not call Application methods which wait
+// for the event loop, such as QueueUpdateSync.
 //
 // Provide nil to uninstall the callback function.
 func (a *Application) SetAfterResizeFunc(handler func(width int, height int)) {
@@ -815,8 +1234,8 @@ func (a *Application) GetAfterResizeFunc() func(width int, height int) {
 // to that primitive. Callers must ensure that the primitive will handle key
 // events.
 //
-// Blur() will be called on the previously focused primitive. Focus() will be
-// called on the new primitive.
+// Blur() will be called on the previously focused primitive, unless the same
+// primitive is focused again. Focus() will be called on the new primitive.
 func (a *Application) SetFocus(p Primitive) {
 	a.Lock()
 
@@ -829,22 +1248,22 @@ func (a *Application) SetFocus(p Primitive) {
 		a.Lock()
 	}
 
-	if a.focus != nil {
-		a.focus.Blur()
-	}
-
+	previous := a.focus
 	a.focus = p
 
 	if a.screen != nil {
 		a.screen.HideCursor()
 	}
 
-	if a.afterFocus != nil {
-		a.Unlock()
+	afterFocus := a.afterFocus
+	a.Unlock()
 
-		a.afterFocus(p)
-	} else {
-		a.Unlock()
+	if previous != nil && previous != p {
+		previous.Blur()
+	}
+
+	if afterFocus != nil {
+		afterFocus(p)
 	}
 
 	if p != nil {
@@ -852,6 +1271,78 @@ func (a *Application) SetFocus(p Primitive) {
 			a.SetFocus(p)
 		})
 	}
+
+	a.RLock()
+	w := a.accessibilityWriter
+	focus := a.focus
+	a.RUnlock()
+	if w != nil && p != nil && focus == p {
+		fmt.Fprintln(w, describePrimitive(p))
+	}
+}
+
+// SetAccessibilityWriter sets a writer which receives a line of text
+// describing the focused primitive whenever the focus changes, to be used by
+// assistive technologies and scripts. Each line consists of the type of the
+// primitive, its label or title and its current value, formatted as:
+//
+//	type=InputField label="Name" value="Alice"
+//
+// Provide nil to disable.
+func (a *Application) SetAccessibilityWriter(w io.Writer) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.accessibilityWriter = w
+}
+
+// describePrimitive returns a description of a primitive for assistive
+// technologies. See SetAccessibilityWriter.
+func describePrimitive(p Primitive) string {
+	var label, value string
+	if l, ok := p.(interface{ GetLabel() string }); ok {
+		label = l.GetLabel()
+	}
+	if t, ok := p.(interface{ GetTitle() string }); ok && label == "" {
+		label = t.GetTitle()
+	}
+
+	switch p := p.(type) {
+	case *InputField:
+		value = p.GetText()
+	case *CheckBox:
+		switch p.GetState() {
+		case CheckBoxChecked:
+			value = "checked"
+		case CheckBoxIndeterminate:
+			value = "indeterminate"
+		default:
+			value = "unchecked"
+		}
+	case *DropDown:
+		if _, option := p.GetCurrentOption(); option != nil {
+			value = option.GetText()
+		}
+	case *Slider:
+		value = fmt.Sprintf("%d", p.GetProgress())
+	case *List:
+		if item := p.GetCurrentItem(); item != nil {
+			value = item.GetMainText()
+		}
+	case *TreeView:
+		if node := p.GetCurrentNode(); node != nil {
+			value = node.GetText()
+		}
+	case *Table:
+		if cell := p.GetCell(p.GetSelection()); cell != nil {
+			value = cell.GetText()
+		}
+	}
+
+	typeName := strings.TrimPrefix(fmt.Sprintf("%T", p), "*cview.")
+	label = string(StripTags([]byte(label), true, true))
+	value = string(StripTags([]byte(value), true, true))
+	return fmt.Sprintf("type=%s label=%q value=%q", typeName, label, value)
 }
 
 // GetFocus returns the primitive which has the current focus. If none has it,
@@ -863,6 +1354,31 @@ func (a *Application) GetFocus() Primitive {
 	return a.focus
 }
 
+// PushFocus saves the primitive which currently has focus. Call PopFocus to
+// restore focus to the saved primitive, e.g. after closing a modal.
+func (a *Application) PushFocus() {
+	a.Lock()
+	defer a.Unlock()
+
+	a.focusStack = append(a.focusStack, a.focus)
+}
+
+// PopFocus restores focus to the primitive most recently saved with PushFocus.
+// Nothing happens when no primitive has been saved.
+func (a *Application) PopFocus() {
+	a.Lock()
+	if len(a.focusStack) == 0 {
+		a.Unlock()
+		return
+	}
+	p := a.focusStack[len(a.focusStack)-1]
+	a.focusStack[len(a.focusStack)-1] = nil
+	a.focusStack = a.focusStack[:len(a.focusStack)-1]
+	a.Unlock()
+
+	a.SetFocus(p)
+}
+
 // SetBeforeFocusFunc installs a callback function which is invoked before the
 // application's focus changes. Return false to maintain the current focus.
 //
@@ -874,6 +1390,21 @@ func (a *Application) SetBeforeFocusFunc(handler func(p Primitive) bool) {
 	a.beforeFocus = handler
 }
 
+// SetBeforeStopFunc installs a callback function which is invoked before the
+// application stops, either because Stop() was called or because Ctrl-C was
+// pressed. Return false to cancel stopping, e.g. to ask the user to confirm
+// quitting first. To stop the application once the user has confirmed, either
+// return true from subsequent calls or uninstall the handler before calling
+// Stop() again.
+//
+// Provide nil to uninstall the callback function.
+func (a *Application) SetBeforeStopFunc(handler func() bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.beforeStop = handler
+}
+
 // SetAfterFocusFunc installs a callback function which is invoked after the
 // application's focus changes.
 //
@@ -895,6 +1426,23 @@ func (a *Application) QueueUpdate(f func()) {
 	a.updates <- f
 }
 
+// QueueUpdateSync works like QueueUpdate() except it blocks until f has been
+// executed. This allows reading the state of primitives right after changing
+// it from another goroutine.
+//
+// Note that this function must not be called from the event loop, e.g. from
+// an input handler or a function passed to QueueUpdate(), as it would wait for
+// itself and block forever. It also blocks forever if the application is not
+// running.
+func (a *Application) QueueUpdateSync(f func()) {
+	done := make(chan struct{})
+	a.QueueUpdate(func() {
+		defer close(done)
+		f()
+	})
+	<-done
+}
+
 // QueueUpdateDraw works like QueueUpdate() except, when one or more primitives
 // are provided, the primitives are drawn after the provided function returns.
 // When no primitives are provided, the entire screen is drawn after the
@@ -918,6 +1466,66 @@ func (a *Application) QueueUpdateDraw(f func(), p ...Primitive) {
 	})
 }
 
+// PauseInput stops the application from processing key, mouse and paste
+// events until ResumeInput is called. Events which occur while input is
+// paused are discarded, even if they are processed after input was resumed
+// (e.g. when a key handler blocks while input is paused). Other events,
+// updates and drawing are not affected.
+//
+// Calls may be nested. Input is resumed when ResumeInput has been called as
+// many times as PauseInput.
+func (a *Application) PauseInput() {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.inputPaused == 0 {
+		a.inputPausedAt = time.Now()
+	}
+	a.inputPaused++
+}
+
+// ResumeInput resumes processing input events after PauseInput was called.
+// Calling ResumeInput more often than PauseInput has no effect.
+func (a *Application) ResumeInput() {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.inputPaused == 0 {
+		return
+	}
+	a.inputPaused--
+	if a.inputPaused == 0 {
+		a.inputResumedAt = time.Now()
+	}
+}
+
+// IsInputPaused returns whether or not input is paused.
+func (a *Application) IsInputPaused() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.inputPaused > 0
+}
+
+// inputDiscarded returns whether or not the provided event is an input event
+// which occurred while input was paused.
+func (a *Application) inputDiscarded(event tcell.Event) bool {
+	switch event.(type) {
+	case *tcell.EventKey, *tcell.EventMouse, *tcell.EventPaste, *pasteEvent:
+	default:
+		return false
+	}
+
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.inputPaused > 0 {
+		return true
+	}
+	when := event.When()
+	return !when.Before(a.inputPausedAt) && when.Before(a.inputResumedAt)
+}
+
 // QueueEvent sends an event to the Application event loop.
 //
 // It is not recommended for event to be nil.
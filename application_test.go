@@ -0,0 +1,538 @@
+package cview
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestApplicationMaxFPS(t *testing.T) {
+	t.Parallel()
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Fatalf("failed to initialize screen: %s", err)
+	}
+
+	// Discard the draw queued by SetRoot.
+	<-app.updates
+
+	var draws int
+	app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		draws++
+		return false
+	})
+	app.SetMaxFPS(10)
+
+	for i := 0; i < 5; i++ {
+		app.draw()
+	}
+	if draws != 1 {
+		t.Errorf("failed to limit draws: expected 1, got %d", draws)
+	}
+
+	// The skipped draws are coalesced into a single queued draw.
+	select {
+	case update := <-app.updates:
+		update()
+	case <-time.After(time.Second):
+		t.Fatal("failed to limit draws: skipped draw was not queued")
+	}
+	if draws != 2 {
+		t.Errorf("failed to limit draws: expected 2, got %d", draws)
+	}
+	select {
+	case <-app.updates:
+		t.Error("failed to limit draws: unexpected queued update")
+	default:
+	}
+}
+
+func TestApplicationDrawFuncs(t *testing.T) {
+	t.Parallel()
+
+	var rootDraws int
+	root := NewBox()
+	root.SetDrawFunc(func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+		rootDraws++
+		return x, y, width, height
+	})
+
+	app, err := newTestApp(root)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Fatalf("failed to initialize screen: %s", err)
+	}
+
+	app.SetRoot(root, false)
+	root.SetRect(0, 0, 10, 10)
+
+	var before, after int
+	skip := true
+	app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		before++
+		return skip
+	})
+	app.SetAfterDrawFunc(func(screen tcell.Screen) {
+		after++
+		screen.SetContent(0, 0, 'X', nil, tcell.StyleDefault)
+	})
+
+	app.draw()
+	if before != 1 || rootDraws != 0 || after != 0 {
+		t.Errorf("failed to skip draw: expected 1 0 0, got %d %d %d", before, rootDraws, after)
+	}
+
+	skip = false
+	app.draw()
+	if before != 2 || rootDraws != 1 || after != 1 {
+		t.Errorf("failed to draw: expected 2 1 1, got %d %d %d", before, rootDraws, after)
+	}
+	if r, _, _, _ := app.screen.GetContent(0, 0); r != 'X' {
+		t.Errorf("failed to draw after root: expected X, got %c", r)
+	}
+}
+
+func TestApplicationFocusStack(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewBox(), NewBox()
+
+	app, err := newTestApp(a)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	// Popping an empty stack does nothing.
+	app.SetFocus(a)
+	app.PopFocus()
+	if app.GetFocus() != a {
+		t.Error("failed to pop empty focus stack: focus changed")
+	}
+
+	app.PushFocus()
+	app.SetFocus(b)
+	app.PushFocus()
+	app.SetFocus(nil)
+
+	app.PopFocus()
+	if app.GetFocus() != b {
+		t.Error("failed to pop focus: expected second box")
+	}
+	app.PopFocus()
+	if app.GetFocus() != a {
+		t.Error("failed to pop focus: expected first box")
+	}
+	if !a.HasFocus() || b.HasFocus() {
+		t.Error("failed to pop focus: focus state not updated")
+	}
+}
+
+func TestApplicationScreenshot(t *testing.T) {
+	t.Parallel()
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Fatalf("failed to initialize screen: %s", err)
+	}
+	app.screen.SetSize(4, 2)
+	app.screen.Clear()
+
+	style := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.NewRGBColor(1, 2, 3)).Bold(true)
+	app.screen.SetContent(0, 0, 'a', nil, style)
+	app.screen.SetContent(1, 0, 'b', nil, tcell.StyleDefault)
+	app.screen.SetContent(0, 1, '世', nil, tcell.StyleDefault)
+	app.screen.Show()
+
+	expected := "ab  \n世  \n"
+	if s := app.Screenshot(); s != expected {
+		t.Errorf("failed to take screenshot: expected %q, got %q", expected, s)
+	}
+
+	expected = "\x1b[0;1;38;5;9;48;2;1;2;3ma\x1b[0mb  \n世  \n"
+	if s := app.ScreenshotANSI(); s != expected {
+		t.Errorf("failed to take ANSI screenshot: expected %q, got %q", expected, s)
+	}
+}
+
+func TestApplicationAccessibilityWriter(t *testing.T) {
+	t.Parallel()
+
+	f := NewForm()
+	f.AddInputField("Name", "Alice", 0, nil, nil)
+	f.AddCheckBox("Subscribe", "", true, nil)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	var b bytes.Buffer
+	app.SetAccessibilityWriter(&b)
+
+	app.SetFocus(f)
+	app.SetFocus(f.GetFormItem(1))
+
+	expected := "type=InputField label=\"Name\" value=\"Alice\"\n" +
+		"type=CheckBox label=\"Subscribe\" value=\"checked\"\n"
+	if b.String() != expected {
+		t.Errorf("failed to write focused primitives: expected %q, got %q", expected, b.String())
+	}
+
+	app.SetAccessibilityWriter(nil)
+	app.SetFocus(f)
+	if b.String() != expected {
+		t.Errorf("failed to disable accessibility writer: got %q", b.String())
+	}
+}
+
+func TestApplicationQueueUpdateSync(t *testing.T) {
+	t.Parallel()
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	// Discard the draw queued by SetRoot.
+	<-app.updates
+
+	// Process queued updates as the event loop would.
+	go func() {
+		for update := range app.updates {
+			time.Sleep(time.Millisecond)
+			update()
+		}
+	}()
+
+	var updated bool
+	app.QueueUpdateSync(func() {
+		updated = true
+	})
+	if !updated {
+		t.Error("failed to wait for queued update")
+	}
+}
+
+func TestApplicationPauseInput(t *testing.T) {
+	t.Parallel()
+
+	app := NewApplication()
+
+	app.PauseInput()
+	app.PauseInput()
+	during := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+	resize := tcell.NewEventResize(80, 24)
+	app.ResumeInput()
+	if !app.IsInputPaused() {
+		t.Fatalf("failed to nest pauses: input was resumed")
+	} else if !app.inputDiscarded(during) {
+		t.Errorf("failed to discard key event while paused")
+	} else if app.inputDiscarded(resize) {
+		t.Errorf("failed to process resize event while paused")
+	}
+
+	app.ResumeInput()
+	app.ResumeInput()
+	after := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+	if app.IsInputPaused() {
+		t.Fatalf("failed to resume input")
+	} else if !app.inputDiscarded(during) {
+		t.Errorf("failed to discard key event which occurred while paused")
+	} else if app.inputDiscarded(after) {
+		t.Errorf("failed to process key event after resuming")
+	}
+}
+
+func TestApplicationSimulationScreen(t *testing.T) {
+	t.Parallel()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 3)
+
+	changed := make(chan string, 1)
+	i := NewInputField()
+	i.SetChangedFunc(func(text string) {
+		changed <- text
+	})
+
+	app := NewApplication()
+	app.SetScreen(screen)
+	app.SetRoot(i, true)
+
+	done := make(chan error)
+	go func() {
+		done <- app.Run()
+	}()
+	app.QueueUpdateSync(func() {})
+
+	if width, height := screen.Size(); width != 20 || height != 3 {
+		t.Errorf("failed to keep screen size: expected 20x3, got %dx%d", width, height)
+	}
+
+	screen.InjectKey(tcell.KeyRune, 'a', tcell.ModNone)
+	if text := <-changed; text != "a" {
+		t.Errorf("failed to handle injected key: expected a, got %s", text)
+	}
+	app.QueueUpdateSync(func() {})
+
+	cells, width, _ := screen.GetContents()
+	if width != 20 {
+		t.Errorf("failed to render to simulation screen: expected width 20, got %d", width)
+	} else if r := cells[0].Runes; len(r) == 0 || r[0] != 'a' {
+		t.Errorf("failed to render to simulation screen: expected a, got %v", r)
+	}
+
+	app.Stop()
+	if err := <-done; err != nil {
+		t.Errorf("failed to run application: %s", err)
+	}
+}
+
+func TestApplicationPauseInputPaste(t *testing.T) {
+	t.Parallel()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(20, 3)
+
+	changed := make(chan string, 1)
+	i := NewInputField()
+	i.SetChangedFunc(func(text string) {
+		changed <- text
+	})
+
+	app := NewApplication()
+	app.SetScreen(screen)
+	app.SetRoot(i, true)
+
+	done := make(chan error)
+	go func() {
+		done <- app.Run()
+	}()
+	app.QueueUpdateSync(func() {})
+
+	// Input is paused in the middle of a paste.
+	app.QueueEvent(tcell.NewEventPaste(true))
+	app.QueueEvent(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone))
+	app.PauseInput()
+	app.QueueEvent(tcell.NewEventPaste(false))
+	app.ResumeInput()
+	app.QueueEvent(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone))
+
+	select {
+	case text := <-changed:
+		if text != "x" {
+			t.Errorf("failed to drop paste while paused: expected x, got %s", text)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("failed to handle key after paste was discarded")
+	}
+
+	app.Stop()
+	if err := <-done; err != nil {
+		t.Errorf("failed to run application: %s", err)
+	}
+}
+
+func TestApplicationBeforeStop(t *testing.T) {
+	t.Parallel()
+
+	app, screen, done := runTestApp(NewBox())
+
+	calls := make(chan bool, 1)
+	var allow bool
+	app.SetBeforeStopFunc(func() bool {
+		calls <- allow
+		return allow
+	})
+
+	// A veto keeps the application running.
+	app.Stop()
+	<-calls
+	app.QueueUpdateSync(func() {})
+	select {
+	case <-done:
+		t.Fatalf("failed to veto stopping the application")
+	default:
+	}
+
+	// Ctrl-C passes through the handler.
+	screen.InjectKey(tcell.KeyCtrlC, 0, tcell.ModCtrl)
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatalf("failed to call handler on Ctrl-C")
+	}
+	app.QueueUpdateSync(func() {})
+	select {
+	case <-done:
+		t.Fatalf("failed to veto stopping the application on Ctrl-C")
+	default:
+	}
+
+	// Returning true stops the application.
+	allow = true
+	app.Stop()
+	<-calls
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("failed to run application: %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("failed to stop the application")
+	}
+}
+
+func TestApplicationShortcuts(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan string, 10)
+	boxes := []*Box{NewBox(), NewBox()}
+	for i, box := range boxes {
+		i := i
+		box.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			received <- fmt.Sprintf("box%d %s", i, event.Name())
+			return event
+		})
+	}
+	f := NewFlex()
+	f.AddItem(boxes[0], 0, 1, true)
+	f.AddItem(boxes[1], 0, 1, false)
+
+	app, screen, done := runTestApp(f)
+	defer func() {
+		app.Stop()
+		<-done
+	}()
+
+	app.RegisterShortcut(tcell.KeyCtrlS, tcell.ModCtrl, func() bool {
+		received <- "save"
+		return true
+	})
+	app.RegisterShortcut(tcell.KeyF2, tcell.ModNone, func() bool {
+		received <- "f2"
+		return false
+	})
+
+	expect := func(expected ...string) {
+		t.Helper()
+		for _, e := range expected {
+			select {
+			case r := <-received:
+				if r != e {
+					t.Errorf("failed to dispatch key: expected %s, got %s", e, r)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("failed to dispatch key: expected %s", e)
+			}
+		}
+	}
+
+	// Shortcuts are handled regardless of which primitive has focus.
+	screen.InjectKey(tcell.KeyCtrlS, 0, tcell.ModCtrl)
+	screen.InjectKey(tcell.KeyRune, 'x', tcell.ModNone)
+	expect("save", "box0 Rune[x]")
+	app.QueueUpdateSync(func() {
+		app.SetFocus(boxes[1])
+	})
+	screen.InjectKey(tcell.KeyCtrlS, 0, tcell.ModCtrl)
+	screen.InjectKey(tcell.KeyRune, 'x', tcell.ModNone)
+	expect("save", "box1 Rune[x]")
+
+	// Unhandled shortcuts are passed on to the focused primitive.
+	screen.InjectKey(tcell.KeyF2, 0, tcell.ModNone)
+	expect("f2", "box1 F2")
+
+	// Unregistered shortcuts are no longer handled.
+	app.UnregisterShortcut(tcell.KeyCtrlS, tcell.ModCtrl)
+	screen.InjectKey(tcell.KeyCtrlS, 0, tcell.ModCtrl)
+	expect("box1 Ctrl+S")
+}
+
+func TestApplicationRunExternal(t *testing.T) {
+	t.Parallel()
+
+	// Commands are not run when the application is not running.
+	cmd := exec.Command("sh", "-c", "exit 0")
+	if err := NewApplication().RunExternal(cmd); err == nil {
+		t.Errorf("failed to return error when the application is not running")
+	}
+	if cmd.Stdin != os.Stdin || cmd.Stdout != os.Stdout || cmd.Stderr != os.Stderr {
+		t.Errorf("failed to attach standard input, output and error to command")
+	} else if cmd.ProcessState != nil {
+		t.Errorf("failed to skip command when the application is not running")
+	}
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	app, _, done := runTestApp(NewBox())
+	defer func() {
+		app.Stop()
+		<-done
+	}()
+
+	// Provided standard streams are kept.
+	var stdout bytes.Buffer
+	cmd = exec.Command("sh", "-c", "echo hello")
+	cmd.Stdout = &stdout
+	if err := app.RunExternal(cmd); err != nil {
+		t.Errorf("failed to run command: %s", err)
+	} else if stdout.String() != "hello\n" {
+		t.Errorf("failed to capture output: expected hello, got %q", stdout.String())
+	} else if cmd.Stdin != os.Stdin || cmd.Stderr != os.Stderr {
+		t.Errorf("failed to attach standard input and error to command")
+	}
+
+	if err := app.RunExternal(exec.Command("sh", "-c", "exit 3")); err == nil {
+		t.Errorf("failed to return error of command")
+	}
+}
+
+func TestApplicationSetFocusAgain(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewBox(), NewBox()
+	var events []string
+	for name, box := range map[string]*Box{"a": a, "b": b} {
+		name := name
+		box.SetFocusFunc(func() {
+			events = append(events, "focus "+name)
+		})
+		box.SetBlurFunc(func() {
+			events = append(events, "blur "+name)
+		})
+	}
+
+	app, err := newTestApp(a)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	app.SetFocus(a)
+	app.SetFocus(a)
+	app.SetFocus(b)
+	app.SetFocus(b)
+
+	expected := []string{"focus a", "blur a", "focus b"}
+	if fmt.Sprint(events) != fmt.Sprint(expected) {
+		t.Errorf("failed to focus primitive again: expected %v, got %v", expected, events)
+	} else if a.HasFocus() || !b.HasFocus() {
+		t.Errorf("failed to focus primitive again: focus state not updated")
+	}
+}
@@ -41,6 +41,16 @@ type Box struct {
 	// The style attributes of the border.
 	borderAttributes tcell.AttrMask
 
+	// Custom border runes. A value of 0 uses the corresponding rune in Borders.
+	borderHorizontal, borderVertical                                   rune
+	borderTopLeft, borderTopRight, borderBottomLeft, borderBottomRight rune
+
+	// Whether or not a shadow is drawn to the bottom-right of the box.
+	shadow bool
+
+	// The background color of the shadow.
+	shadowColor tcell.Color
+
 	// The title. Only visible if there is a border, too.
 	title []byte
 
@@ -50,6 +60,15 @@ type Box struct {
 	// The alignment of the title.
 	titleAlign int
 
+	// An additional title, drawn on the same row as the title.
+	titleExtra []byte
+
+	// The color of the additional title.
+	titleExtraColor tcell.Color
+
+	// The alignment of the additional title.
+	titleExtraAlign int
+
 	// Provides a way to find out if this box has focus. We always go through
 	// this interface because it may be overridden by implementing classes.
 	focus Focusable
@@ -73,6 +92,13 @@ type Box struct {
 	// least one nil if nothing should be forwarded).
 	mouseCapture func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse)
 
+	// An optional function which is called when the box is double clicked.
+	doubleClick func()
+
+	// Optional functions which are called when the box receives and loses
+	// focus.
+	focusFunc, blurFunc func()
+
 	l sync.RWMutex
 }
 
@@ -85,8 +111,11 @@ func NewBox() *Box {
 		backgroundColor:    Styles.PrimitiveBackgroundColor,
 		borderColor:        Styles.BorderColor,
 		titleColor:         Styles.TitleColor,
+		titleExtraColor:    Styles.TitleColor,
+		shadowColor:        tcell.ColorBlack.TrueColor(),
 		borderColorFocused: ColorUnset,
 		titleAlign:         AlignCenter,
+		titleExtraAlign:    AlignRight,
 		showFocus:          true,
 	}
 	b.focus = b
@@ -280,6 +309,16 @@ func (b *Box) WrapMouseHandler(mouseHandler func(MouseAction, *tcell.EventMouse,
 		if event != nil && mouseHandler != nil {
 			consumed, capture = mouseHandler(action, event, setFocus)
 		}
+		if !consumed && event != nil && action == MouseLeftDoubleClick {
+			b.l.RLock()
+			doubleClick := b.doubleClick
+			b.l.RUnlock()
+
+			if doubleClick != nil && b.InRect(event.Position()) {
+				doubleClick()
+				consumed = true
+			}
+		}
 		return
 	}
 }
@@ -307,6 +346,62 @@ func (b *Box) SetMouseCapture(capture func(action MouseAction, event *tcell.Even
 	b.mouseCapture = capture
 }
 
+// SetDoubleClickFunc sets a function which is called when the box is double
+// clicked and the primitive's default mouse event handler does not consume the
+// event.
+//
+// Double clicks are disabled by default, because registering them turns a
+// second click within the interval into a double click for all primitives.
+// Call Application.SetDoubleClickInterval(StandardDoubleClick) to register
+// double clicks within 500 milliseconds, otherwise the handler is never called.
+//
+// Providing a nil handler will remove a previously existing handler.
+func (b *Box) SetDoubleClickFunc(handler func()) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.doubleClick = handler
+}
+
+// SetFocusFunc sets a function which is called when the box receives focus.
+// The function is only called when the box did not have focus before.
+//
+// Primitives which hand on the focus to other primitives, such as Flex and
+// Form, do not call this function when their items receive focus.
+//
+// Providing a nil handler will remove a previously existing handler.
+func (b *Box) SetFocusFunc(handler func()) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.focusFunc = handler
+}
+
+// SetBlurFunc sets a function which is called when the box loses focus. The
+// function is only called when the box had focus before.
+//
+// Providing a nil handler will remove a previously existing handler.
+func (b *Box) SetBlurFunc(handler func()) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.blurFunc = handler
+}
+
+// focusChanged calls the focus or blur handler, if one is set.
+func (b *Box) focusChanged(focus bool) {
+	b.l.RLock()
+	handler := b.blurFunc
+	if focus {
+		handler = b.focusFunc
+	}
+	b.l.RUnlock()
+
+	if handler != nil {
+		handler()
+	}
+}
+
 // InRect returns true if the given coordinate is within the bounds of the box's
 // rectangle.
 func (b *Box) InRect(x, y int) bool {
@@ -393,6 +488,39 @@ func (b *Box) SetBorderAttributes(attr tcell.AttrMask) {
 	b.borderAttributes = attr
 }
 
+// SetBorderRunes sets the runes used to draw the box's border, overriding the
+// runes defined in Borders. Custom runes are used regardless of whether the
+// box has focus. A value of 0 restores the default rune for that part of the
+// border. For example, to draw an ASCII-only border:
+//
+//   box.SetBorderRunes('-', '|', '+', '+', '+', '+')
+func (b *Box) SetBorderRunes(horizontal, vertical, topLeft, topRight, bottomLeft, bottomRight rune) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.borderHorizontal, b.borderVertical = horizontal, vertical
+	b.borderTopLeft, b.borderTopRight = topLeft, topRight
+	b.borderBottomLeft, b.borderBottomRight = bottomLeft, bottomRight
+}
+
+// SetShadow sets the flag indicating whether or not a shadow is drawn one cell
+// to the right of and one cell below the box. The shadow dims the cells it
+// covers. Parts of the shadow which fall outside of the screen are not drawn.
+func (b *Box) SetShadow(shadow bool) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.shadow = shadow
+}
+
+// SetShadowColor sets the background color of the box's shadow.
+func (b *Box) SetShadowColor(color tcell.Color) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.shadowColor = color
+}
+
 // SetTitle sets the box's title.
 func (b *Box) SetTitle(title string) {
 	b.l.Lock()
@@ -426,6 +554,100 @@ func (b *Box) SetTitleAlign(align int) {
 	b.titleAlign = align
 }
 
+// SetTitleExtra sets an additional title which is drawn on the same row as the
+// box's title, e.g. a status shown opposite of the title. The alignment is one
+// of AlignLeft, AlignCenter, or AlignRight. When both titles do not fit, the
+// longer one is truncated. Provide an empty string to remove the additional
+// title.
+func (b *Box) SetTitleExtra(text string, align int) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.titleExtra = []byte(text)
+	b.titleExtraAlign = align
+}
+
+// GetTitleExtra returns the box's current additional title.
+func (b *Box) GetTitleExtra() string {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	return string(b.titleExtra)
+}
+
+// SetTitleExtraColor sets the color of the box's additional title.
+func (b *Box) SetTitleExtraColor(color tcell.Color) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.titleExtraColor = color
+}
+
+// drawTitles draws the title and the additional title on the top border row
+// without overlapping each other.
+func (b *Box) drawTitles(screen tcell.Screen) {
+	x, width := b.x+1, b.width-2
+	titleWidth, extraWidth := TaggedTextWidth(b.title), TaggedTextWidth(b.titleExtra)
+
+	// Truncate the longer title until both titles fit, separated by a space.
+	for titleWidth+extraWidth+1 > width && titleWidth+extraWidth > 0 {
+		if titleWidth >= extraWidth {
+			titleWidth--
+		} else {
+			extraWidth--
+		}
+	}
+
+	start := func(align, w int) int {
+		switch align {
+		case AlignCenter:
+			return x + (width-w)/2
+		case AlignRight:
+			return x + width - w
+		default:
+			return x
+		}
+	}
+	titleX, extraX := start(b.titleAlign, titleWidth), start(b.titleExtraAlign, extraWidth)
+
+	// Move the titles apart when they overlap.
+	if b.titleAlign == b.titleExtraAlign {
+		switch b.titleAlign {
+		case AlignCenter:
+			titleX = x + (width-titleWidth-extraWidth-1)/2
+			extraX = titleX + titleWidth + 1
+		case AlignRight:
+			titleX = extraX - titleWidth - 1
+		default:
+			extraX = titleX + titleWidth + 1
+		}
+	} else if titleX < extraX && titleX+titleWidth >= extraX {
+		if b.titleExtraAlign == AlignRight {
+			titleX = extraX - titleWidth - 1
+		} else {
+			extraX = titleX + titleWidth + 1
+		}
+	} else if extraX < titleX && extraX+extraWidth >= titleX {
+		if b.titleAlign == AlignRight {
+			extraX = titleX - extraWidth - 1
+		} else {
+			titleX = extraX + extraWidth + 1
+		}
+	}
+
+	printTitle := func(text []byte, x, w int, color tcell.Color) {
+		if w <= 0 {
+			return
+		}
+		printed, _ := Print(screen, text, x, b.y, w, AlignLeft, color)
+		if len(text)-printed > 0 {
+			Print(screen, []byte(string(SemigraphicsHorizontalEllipsis)), x+w-1, b.y, 1, AlignLeft, color)
+		}
+	}
+	printTitle(b.title, titleX, titleWidth, b.titleColor)
+	printTitle(b.titleExtra, extraX, extraWidth, b.titleExtraColor)
+}
+
 // Draw draws this primitive onto the screen.
 func (b *Box) Draw(screen tcell.Screen) {
 	b.l.Lock()
@@ -443,6 +665,24 @@ func (b *Box) Draw(screen tcell.Screen) {
 
 	def := tcell.StyleDefault
 
+	// Draw shadow.
+	if b.shadow {
+		screenWidth, screenHeight := screen.Size()
+		dim := func(x, y int) {
+			if x >= screenWidth || y >= screenHeight {
+				return
+			}
+			m, c, style, _ := screen.GetContent(x, y)
+			screen.SetContent(x, y, m, c, style.Background(b.shadowColor).Dim(true))
+		}
+		for y := b.y + 1; y <= b.y+b.height; y++ {
+			dim(b.x+b.width, y)
+		}
+		for x := b.x + 1; x < b.x+b.width; x++ {
+			dim(x, b.y+b.height)
+		}
+	}
+
 	// Fill background.
 	background := def.Background(b.backgroundColor)
 	if !b.backgroundTransparent {
@@ -484,6 +724,24 @@ func (b *Box) Draw(screen tcell.Screen) {
 			bottomLeft = Borders.BottomLeft
 			bottomRight = Borders.BottomRight
 		}
+		if b.borderHorizontal != 0 {
+			horizontal = b.borderHorizontal
+		}
+		if b.borderVertical != 0 {
+			vertical = b.borderVertical
+		}
+		if b.borderTopLeft != 0 {
+			topLeft = b.borderTopLeft
+		}
+		if b.borderTopRight != 0 {
+			topRight = b.borderTopRight
+		}
+		if b.borderBottomLeft != 0 {
+			bottomLeft = b.borderBottomLeft
+		}
+		if b.borderBottomRight != 0 {
+			bottomRight = b.borderBottomRight
+		}
 		for x := b.x + 1; x < b.x+b.width-1; x++ {
 			screen.SetContent(x, b.y, horizontal, nil, border)
 			screen.SetContent(x, b.y+b.height-1, horizontal, nil, border)
@@ -498,7 +756,9 @@ func (b *Box) Draw(screen tcell.Screen) {
 		screen.SetContent(b.x+b.width-1, b.y+b.height-1, bottomRight, nil, border)
 
 		// Draw title.
-		if len(b.title) > 0 && b.width >= 4 {
+		if len(b.titleExtra) > 0 && b.width >= 4 {
+			b.drawTitles(screen)
+		} else if len(b.title) > 0 && b.width >= 4 {
 			printed, _ := Print(screen, b.title, b.x+1, b.y, b.width-2, b.titleAlign, b.titleColor)
 			if len(b.title)-printed > 0 && printed > 0 {
 				_, _, style, _ := screen.GetContent(b.x+b.width-2, b.y)
@@ -514,6 +774,54 @@ func (b *Box) Draw(screen tcell.Screen) {
 	}
 }
 
+// DrawInner calls the provided function with the inner rectangle of the box
+// (see GetInnerRect) and a screen which discards content set outside of it.
+// This is useful for primitives embedding Box to avoid drawing over their
+// border or neighboring primitives.
+func (b *Box) DrawInner(screen tcell.Screen, draw func(screen tcell.Screen, x, y, width, height int)) {
+	x, y, width, height := b.GetInnerRect()
+	draw(&clippedScreen{Screen: screen, x: x, y: y, width: width, height: height}, x, y, width, height)
+}
+
+// clippedScreen is a screen which ignores content set outside of a rectangle.
+type clippedScreen struct {
+	tcell.Screen
+	x, y, width, height int
+}
+
+// SetContent sets the contents of the given cell location if it is located
+// within the rectangle of the screen.
+func (s *clippedScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	if x < s.x || x >= s.x+s.width || y < s.y || y >= s.y+s.height {
+		return
+	}
+	s.Screen.SetContent(x, y, mainc, combc, style)
+}
+
+// SetCell sets the contents of the given cell location if it is located
+// within the rectangle of the screen.
+func (s *clippedScreen) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	if len(ch) > 0 {
+		s.SetContent(x, y, ch[0], ch[1:], style)
+	} else {
+		s.SetContent(x, y, ' ', nil, style)
+	}
+}
+
+// Fill fills the rectangle of the screen with the given character and style.
+func (s *clippedScreen) Fill(r rune, style tcell.Style) {
+	for y := s.y; y < s.y+s.height; y++ {
+		for x := s.x; x < s.x+s.width; x++ {
+			s.Screen.SetContent(x, y, r, nil, style)
+		}
+	}
+}
+
+// Clear clears the rectangle of the screen.
+func (s *clippedScreen) Clear() {
+	s.Fill(' ', tcell.StyleDefault)
+}
+
 // ShowFocus sets the flag indicating whether or not the borders of this
 // primitive should change thickness when focused.
 func (b *Box) ShowFocus(showFocus bool) {
@@ -526,17 +834,25 @@ func (b *Box) ShowFocus(showFocus bool) {
 // Focus is called when this primitive receives focus.
 func (b *Box) Focus(delegate func(p Primitive)) {
 	b.l.Lock()
-	defer b.l.Unlock()
-
+	hadFocus := b.hasFocus
 	b.hasFocus = true
+	b.l.Unlock()
+
+	if !hadFocus {
+		b.focusChanged(true)
+	}
 }
 
 // Blur is called when this primitive loses focus.
 func (b *Box) Blur() {
 	b.l.Lock()
-	defer b.l.Unlock()
-
+	hadFocus := b.hasFocus
 	b.hasFocus = false
+	b.l.Unlock()
+
+	if hadFocus {
+		b.focusChanged(false)
+	}
 }
 
 // HasFocus returns whether or not this primitive has focus.
@@ -2,6 +2,8 @@ package cview
 
 import (
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -54,3 +56,250 @@ func TestBox(t *testing.T) {
 
 	b.Draw(app.screen)
 }
+
+func TestBoxBorderRunes(t *testing.T) {
+	t.Parallel()
+
+	b := NewBox()
+	b.SetBorder(true)
+	b.SetBorderRunes('-', '|', '+', '+', '+', 0)
+
+	app, err := newTestApp(b)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	b.Blur()
+	b.SetRect(0, 0, 5, 3)
+	b.Draw(app.screen)
+
+	for _, c := range []struct {
+		x, y     int
+		expected rune
+	}{
+		{0, 0, '+'},
+		{1, 0, '-'},
+		{4, 0, '+'},
+		{0, 1, '|'},
+		{0, 2, '+'},
+		{4, 2, Borders.BottomRight},
+	} {
+		if r, _, _, _ := app.screen.GetContent(c.x, c.y); r != c.expected {
+			t.Errorf("failed to draw Box border at %d,%d: expected %c, got %c", c.x, c.y, c.expected, r)
+		}
+	}
+}
+
+func TestBoxPadding(t *testing.T) {
+	t.Parallel()
+
+	b := NewBox()
+	b.SetRect(0, 0, 20, 10)
+	b.SetBorder(true)
+	b.SetPadding(1, 2, 3, 4)
+
+	x, y, width, height := b.GetInnerRect()
+	if x != 4 || y != 2 || width != 11 || height != 5 {
+		t.Errorf("failed to update Box: incorrect inner rect: expected 4,2 11x5, got %d,%d %dx%d", x, y, width, height)
+	}
+
+	x, y, width, height = b.GetRect()
+	if x != 0 || y != 0 || width != 20 || height != 10 {
+		t.Errorf("failed to update Box: incorrect rect: expected 0,0 20x10, got %d,%d %dx%d", x, y, width, height)
+	}
+
+	// Padding larger than the box
+
+	b.SetPadding(10, 10, 10, 10)
+	_, _, width, height = b.GetInnerRect()
+	if width != 0 || height != 0 {
+		t.Errorf("failed to update Box: incorrect inner rect: expected 0x0, got %dx%d", width, height)
+	}
+}
+
+func TestBoxShadow(t *testing.T) {
+	t.Parallel()
+
+	b := NewBox()
+	b.SetShadow(true)
+	b.SetShadowColor(tcell.ColorRed)
+
+	app, err := newTestApp(b)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	b.SetRect(0, 0, 5, 3)
+	b.Draw(app.screen)
+
+	for _, c := range []struct {
+		x, y   int
+		shadow bool
+	}{
+		{5, 0, false},
+		{5, 1, true},
+		{5, 3, true},
+		{0, 3, false},
+		{1, 3, true},
+		{4, 2, false},
+	} {
+		_, _, style, _ := app.screen.GetContent(c.x, c.y)
+		_, bg, _ := style.Decompose()
+		if (bg == tcell.ColorRed) != c.shadow {
+			t.Errorf("failed to draw Box shadow at %d,%d: expected shadow %v, got background %v", c.x, c.y, c.shadow, bg)
+		}
+	}
+
+	// Flush against the screen edge
+
+	b.SetRect(75, 21, 5, 3)
+	b.Draw(app.screen)
+}
+
+func TestBoxDoubleClickFunc(t *testing.T) {
+	t.Parallel()
+
+	b := NewBox()
+	b.SetRect(0, 0, 10, 5)
+
+	var clicked int
+	b.SetDoubleClickFunc(func() {
+		clicked++
+	})
+
+	setFocus := func(p Primitive) {}
+	handler := b.MouseHandler()
+
+	handler(MouseLeftClick, tcell.NewEventMouse(1, 1, tcell.ButtonNone, tcell.ModNone), setFocus)
+	if clicked != 0 {
+		t.Errorf("failed to handle Box click: expected no double click, got %d", clicked)
+	}
+
+	consumed, _ := handler(MouseLeftDoubleClick, tcell.NewEventMouse(1, 1, tcell.ButtonNone, tcell.ModNone), setFocus)
+	if clicked != 1 || !consumed {
+		t.Errorf("failed to handle Box double click: expected 1 consumed double click, got %d (consumed %v)", clicked, consumed)
+	}
+
+	handler(MouseLeftDoubleClick, tcell.NewEventMouse(20, 20, tcell.ButtonNone, tcell.ModNone), setFocus)
+	if clicked != 1 {
+		t.Errorf("failed to handle Box double click: expected double click outside of box to be ignored")
+	}
+}
+
+func TestBoxFocusFuncs(t *testing.T) {
+	t.Parallel()
+
+	input := NewInputField()
+	list := NewList()
+	flex := NewFlex()
+	flex.AddItem(input, 1, 0, true)
+	flex.AddItem(list, 0, 1, false)
+
+	var events []string
+	input.SetFocusFunc(func() {
+		events = append(events, "input focus")
+	})
+	input.SetBlurFunc(func() {
+		events = append(events, "input blur")
+	})
+	list.SetFocusFunc(func() {
+		events = append(events, "list focus")
+	})
+	list.SetBlurFunc(func() {
+		events = append(events, "list blur")
+	})
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	app.SetFocus(list)
+	app.SetFocus(list)
+	app.SetFocus(input)
+	expected := []string{"input focus", "input blur", "list focus", "list blur", "input focus"}
+	if len(events) != len(expected) {
+		t.Fatalf("failed to call focus functions: expected %v, got %v", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("failed to call focus functions: expected %v, got %v", expected, events)
+		}
+	}
+}
+
+func TestBoxTitleExtra(t *testing.T) {
+	t.Parallel()
+
+	b := NewBox()
+	b.SetBorder(true)
+	b.SetTitle("Logs")
+	b.SetTitleAlign(AlignLeft)
+	b.SetTitleExtra("connected", AlignRight)
+	if b.GetTitleExtra() != "connected" {
+		t.Errorf("failed to update Box: incorrect extra title: expected connected, got %s", b.GetTitleExtra())
+	}
+
+	app, err := newTestApp(b)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	b.SetRect(0, 0, 20, 3)
+	b.Draw(app.screen)
+	expectBoxRow(t, app, 1, "Logs")
+	expectBoxRow(t, app, 10, "connected")
+
+	// Truncate when there is not enough space
+
+	b.SetRect(0, 0, 10, 3)
+	b.Draw(app.screen)
+	expectBoxRow(t, app, 1, "Lo"+string(SemigraphicsHorizontalEllipsis))
+	expectBoxRow(t, app, 5, "con"+string(SemigraphicsHorizontalEllipsis))
+}
+
+func expectBoxRow(t *testing.T, app *Application, x int, expected string) {
+	t.Helper()
+
+	for _, r := range expected {
+		if got, _, _, _ := app.screen.GetContent(x, 0); got != r {
+			t.Errorf("failed to draw Box title at %d: expected %c, got %c", x, r, got)
+		}
+		x++
+	}
+}
+
+func TestBoxDrawInner(t *testing.T) {
+	t.Parallel()
+
+	b := NewBox()
+	b.SetBorder(true)
+
+	app, err := newTestApp(b)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	b.SetRect(2, 1, 6, 4)
+	b.Draw(app.screen)
+
+	b.DrawInner(app.screen, func(screen tcell.Screen, x, y, width, height int) {
+		if x != 3 || y != 2 || width != 4 || height != 2 {
+			t.Errorf("failed to provide inner rect: got %d,%d %dx%d", x, y, width, height)
+		}
+		for yy := 0; yy < 6; yy++ {
+			for xx := 0; xx < 10; xx++ {
+				screen.SetContent(xx, yy, 'x', nil, tcell.StyleDefault)
+			}
+		}
+	})
+
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			inner := x >= 3 && x < 7 && y >= 2 && y < 4
+			if inner != (r == 'x') {
+				t.Errorf("failed to clip drawing at %d,%d: got %c", x, y, r)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
 )
 
 // Button is labeled box that triggers an action when selected.
@@ -22,6 +23,12 @@ type Button struct {
 	// The background color when the button is in focus.
 	backgroundColorFocused tcell.Color
 
+	// The label color when the button is disabled.
+	labelColorDisabled tcell.Color
+
+	// Whether or not the button is disabled.
+	disabled bool
+
 	// An optional function which is called when the button was selected.
 	selected func()
 
@@ -32,6 +39,13 @@ type Button struct {
 	// An optional rune which is drawn after the label when the button is focused.
 	cursorRune rune
 
+	// An optional rune which is drawn in front of the label.
+	icon rune
+
+	// An optional text which is drawn at the right edge of the button, e.g.
+	// the button's keyboard shortcut.
+	shortcutText []byte
+
 	sync.RWMutex
 }
 
@@ -47,6 +61,7 @@ func NewButton(label string) *Button {
 		labelColorFocused:      Styles.PrimaryTextColor,
 		cursorRune:             Styles.ButtonCursorRune,
 		backgroundColorFocused: Styles.ContrastBackgroundColor,
+		labelColorDisabled:     Styles.ContrastSecondaryTextColor,
 	}
 }
 
@@ -83,6 +98,68 @@ func (b *Button) SetLabelColorFocused(color tcell.Color) {
 	b.labelColorFocused = color
 }
 
+// SetLabelColorDisabled sets the color of the button text when the button is
+// disabled.
+func (b *Button) SetLabelColorDisabled(color tcell.Color) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.labelColorDisabled = color
+}
+
+// SetDisabled sets whether or not the button is disabled. Disabled buttons are
+// drawn dimmed, are skipped when navigating a Form and may not be selected.
+func (b *Button) SetDisabled(disabled bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.disabled = disabled
+}
+
+// IsDisabled returns whether or not the button is disabled.
+func (b *Button) IsDisabled() bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.disabled
+}
+
+// SetIcon sets a rune which is drawn in front of the label. A value of 0
+// removes the icon.
+func (b *Button) SetIcon(icon rune) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.icon = icon
+}
+
+// SetShortcutText sets a text which is drawn at the right edge of the button,
+// e.g. "^S". The text is only displayed. Key bindings must be set up
+// separately, e.g. with Application.RegisterShortcut. When there isn't enough
+// space, the label is truncated first.
+func (b *Button) SetShortcutText(text string) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.shortcutText = []byte(text)
+}
+
+// contentWidth returns the screen width of the label including the icon and
+// shortcut text.
+func (b *Button) contentWidth() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	width := TaggedStringWidth(string(b.label))
+	if b.icon != 0 {
+		width += runewidth.RuneWidth(b.icon) + 1
+	}
+	if len(b.shortcutText) > 0 {
+		width += TaggedStringWidth(string(b.shortcutText)) + 2
+	}
+	return width
+}
+
 // SetCursorRune sets the rune to show within the button when it is focused.
 func (b *Button) SetCursorRune(rune rune) {
 	b.Lock()
@@ -134,7 +211,8 @@ func (b *Button) Draw(screen tcell.Screen) {
 	// Draw the box.
 	borderColor := b.borderColor
 	backgroundColor := b.backgroundColor
-	if b.focus.HasFocus() {
+	hasFocus := b.focus.HasFocus() && !b.disabled
+	if hasFocus {
 		b.backgroundColor = b.backgroundColorFocused
 		b.borderColor = b.labelColorFocused
 		defer func() {
@@ -151,13 +229,29 @@ func (b *Button) Draw(screen tcell.Screen) {
 	if width > 0 && height > 0 {
 		y = y + height/2
 		labelColor := b.labelColor
-		if b.focus.HasFocus() {
+		if b.disabled {
+			labelColor = b.labelColorDisabled
+		} else if hasFocus {
 			labelColor = b.labelColorFocused
 		}
-		_, pw := Print(screen, b.label, x, y, width, AlignCenter, labelColor)
+
+		// Draw shortcut text.
+		if len(b.shortcutText) > 0 {
+			_, sw := Print(screen, b.shortcutText, x, y, width, AlignRight, labelColor)
+			width -= sw + 2
+			if width < 0 {
+				width = 0
+			}
+		}
+
+		label := b.label
+		if b.icon != 0 {
+			label = append([]byte(Escape(string(b.icon))+" "), label...)
+		}
+		_, pw := Print(screen, label, x, y, width, AlignCenter, labelColor)
 
 		// Draw cursor.
-		if b.focus.HasFocus() && b.cursorRune != 0 {
+		if hasFocus && b.cursorRune != 0 {
 			cursorX := x + int(float64(width)/2+float64(pw)/2)
 			if cursorX > x+width-1 {
 				cursorX = x + width - 1
@@ -174,7 +268,7 @@ func (b *Button) InputHandler() func(event *tcell.EventKey, setFocus func(p Prim
 	return b.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		// Process key event.
 		if HitShortcut(event, Keys.Select, Keys.Select2) {
-			if b.selected != nil {
+			if b.selected != nil && !b.IsDisabled() {
 				b.selected()
 			}
 		} else if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
@@ -194,6 +288,9 @@ func (b *Button) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 
 		// Process mouse event.
 		if action == MouseLeftClick {
+			if b.IsDisabled() {
+				return true, nil
+			}
 			setFocus(b)
 			if b.selected != nil {
 				b.selected()
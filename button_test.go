@@ -1,6 +1,7 @@
 package cview
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -40,3 +41,39 @@ func TestButton(t *testing.T) {
 
 	b.Draw(app.screen)
 }
+
+func TestButtonIconShortcut(t *testing.T) {
+	t.Parallel()
+
+	b := NewButton("Save")
+	b.SetIcon('*')
+	b.SetShortcutText("^S")
+	b.SetCursorRune(0)
+
+	app, err := newTestApp(b)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	testCases := []struct {
+		width    int
+		expected string
+	}{
+		{14, "  * Save    ^S"},
+		{9, "* Sav  ^S"},
+		{2, "^S"},
+	}
+	for _, c := range testCases {
+		b.SetRect(0, 0, c.width, 1)
+		b.Draw(app.screen)
+
+		var row []rune
+		for x := 0; x < c.width; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			row = append(row, r)
+		}
+		if got := strings.TrimRight(string(row), " "); got != c.expected {
+			t.Errorf("failed to draw Button at width %d: expected %q, got %q", c.width, c.expected, got)
+		}
+	}
+}
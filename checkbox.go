@@ -6,6 +6,16 @@ import (
 	"github.com/gdamore/tcell/v2"
 )
 
+// CheckBoxState represents the state of a CheckBox.
+type CheckBoxState int
+
+// Checkbox states.
+const (
+	CheckBoxUnchecked CheckBoxState = iota
+	CheckBoxChecked
+	CheckBoxIndeterminate
+)
+
 // CheckBox implements a simple box for boolean values which can be checked and
 // unchecked.
 type CheckBox struct {
@@ -14,6 +24,9 @@ type CheckBox struct {
 	// Whether or not this box is checked.
 	checked bool
 
+	// Whether or not this box is in the indeterminate state.
+	indeterminate bool
+
 	// The text to be displayed before the checkbox.
 	label []byte
 
@@ -46,6 +59,10 @@ type CheckBox struct {
 	// state of this checkbox.
 	changed func(checked bool)
 
+	// An optional function which notifies the form this item belongs to when
+	// the value was changed by the user.
+	formChanged func()
+
 	// An optional function which is called when the user indicated that they
 	// are done entering text. The key which was pressed is provided (tab,
 	// shift-tab, or escape).
@@ -58,6 +75,9 @@ type CheckBox struct {
 	// The rune to show when the checkbox is checked
 	checkedRune rune
 
+	// The rune to show when the checkbox is indeterminate
+	indeterminateRune rune
+
 	// An optional rune to show within the checkbox when it is focused
 	cursorRune rune
 
@@ -73,18 +93,53 @@ func NewCheckBox() *CheckBox {
 		fieldBackgroundColorFocused: Styles.ContrastBackgroundColor,
 		fieldTextColor:              Styles.PrimaryTextColor,
 		checkedRune:                 Styles.CheckBoxCheckedRune,
+		indeterminateRune:           Styles.CheckBoxIndeterminateRune,
 		cursorRune:                  Styles.CheckBoxCursorRune,
 		labelColorFocused:           ColorUnset,
 		fieldTextColorFocused:       ColorUnset,
 	}
 }
 
-// SetChecked sets the state of the checkbox.
+// SetChecked sets the state of the checkbox. The indeterminate state is
+// cleared.
 func (c *CheckBox) SetChecked(checked bool) {
 	c.Lock()
 	defer c.Unlock()
 
 	c.checked = checked
+	c.indeterminate = false
+}
+
+// SetIndeterminate sets whether or not the checkbox is in the indeterminate
+// state, which is typically used to indicate that only some of a group of
+// options are checked. Toggling an indeterminate checkbox checks it.
+func (c *CheckBox) SetIndeterminate(indeterminate bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.indeterminate = indeterminate
+}
+
+// IsIndeterminate returns whether or not the checkbox is in the indeterminate
+// state.
+func (c *CheckBox) IsIndeterminate() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.indeterminate
+}
+
+// GetState returns the state of the checkbox.
+func (c *CheckBox) GetState() CheckBoxState {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.indeterminate {
+		return CheckBoxIndeterminate
+	} else if c.checked {
+		return CheckBoxChecked
+	}
+	return CheckBoxUnchecked
 }
 
 // SetCheckedRune sets the rune to show when the checkbox is checked.
@@ -95,6 +150,15 @@ func (c *CheckBox) SetCheckedRune(rune rune) {
 	c.checkedRune = rune
 }
 
+// SetIndeterminateRune sets the rune to show when the checkbox is
+// indeterminate.
+func (c *CheckBox) SetIndeterminateRune(rune rune) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.indeterminateRune = rune
+}
+
 // SetCursorRune sets the rune to show within the checkbox when it is focused.
 func (c *CheckBox) SetCursorRune(rune rune) {
 	c.Lock()
@@ -103,12 +167,13 @@ func (c *CheckBox) SetCursorRune(rune rune) {
 	c.cursorRune = rune
 }
 
-// IsChecked returns whether or not the box is checked.
+// IsChecked returns whether or not the box is checked. An indeterminate
+// checkbox is not checked.
 func (c *CheckBox) IsChecked() bool {
 	c.RLock()
 	defer c.RUnlock()
 
-	return c.checked
+	return c.checked && !c.indeterminate
 }
 
 // SetLabel sets the text to be displayed before the input area.
@@ -227,6 +292,15 @@ func (c *CheckBox) SetChangedFunc(handler func(checked bool)) {
 	c.changed = handler
 }
 
+// setFormChangedFunc sets a handler which is called when the value of this
+// checkbox was changed by the user. It is used by Form.
+func (c *CheckBox) setFormChangedFunc(handler func()) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.formChanged = handler
+}
+
 // SetDoneFunc sets a handler which is called when the user is done using the
 // checkbox. The callback function is provided with the key that was pressed,
 // which is one of the following:
@@ -302,7 +376,9 @@ func (c *CheckBox) Draw(screen tcell.Screen) {
 	fieldStyle := tcell.StyleDefault.Background(fieldBackgroundColor).Foreground(fieldTextColor)
 
 	checkedRune := c.checkedRune
-	if !c.checked {
+	if c.indeterminate {
+		checkedRune = c.indeterminateRune
+	} else if !c.checked {
 		checkedRune = ' '
 	}
 	rightRune := ' '
@@ -318,16 +394,34 @@ func (c *CheckBox) Draw(screen tcell.Screen) {
 	}
 }
 
+// toggle toggles the checked state of the checkbox and calls the changed
+// handler. An indeterminate checkbox becomes checked.
+func (c *CheckBox) toggle() {
+	c.Lock()
+	if c.indeterminate {
+		c.checked = true
+		c.indeterminate = false
+	} else {
+		c.checked = !c.checked
+	}
+	checked := c.checked
+	changed := c.changed
+	formChanged := c.formChanged
+	c.Unlock()
+
+	if changed != nil {
+		changed(checked)
+	}
+	if formChanged != nil {
+		formChanged()
+	}
+}
+
 // InputHandler returns the handler for this primitive.
 func (c *CheckBox) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		if HitShortcut(event, Keys.Select, Keys.Select2) {
-			c.Lock()
-			c.checked = !c.checked
-			c.Unlock()
-			if c.changed != nil {
-				c.changed(c.checked)
-			}
+			c.toggle()
 		} else if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
 			if c.done != nil {
 				c.done(event.Key())
@@ -351,10 +445,7 @@ func (c *CheckBox) MouseHandler() func(action MouseAction, event *tcell.EventMou
 		// Process mouse event.
 		if action == MouseLeftClick && y == rectY {
 			setFocus(c)
-			c.checked = !c.checked
-			if c.changed != nil {
-				c.changed(c.checked)
-			}
+			c.toggle()
 			consumed = true
 		}
 
@@ -2,6 +2,8 @@ package cview
 
 import (
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -45,6 +47,26 @@ func TestCheckBox(t *testing.T) {
 		t.Errorf("failed to update CheckBox state: incorrect state: expected unchecked, got checked")
 	}
 
+	// Set indeterminate
+
+	c.SetIndeterminate(true)
+	if c.GetState() != CheckBoxIndeterminate {
+		t.Errorf("failed to update CheckBox state: incorrect state: expected indeterminate, got %d", c.GetState())
+	} else if c.IsChecked() {
+		t.Errorf("failed to update CheckBox state: incorrect state: expected unchecked, got checked")
+	}
+
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), nil)
+	if c.GetState() != CheckBoxChecked || c.IsIndeterminate() {
+		t.Errorf("failed to toggle indeterminate CheckBox: incorrect state: expected checked, got %d", c.GetState())
+	}
+
+	c.SetIndeterminate(true)
+	c.SetChecked(false)
+	if c.GetState() != CheckBoxUnchecked {
+		t.Errorf("failed to update CheckBox state: incorrect state: expected unchecked, got %d", c.GetState())
+	}
+
 	// Draw
 
 	app, err := newTestApp(c)
@@ -1,6 +1,10 @@
 package cview
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // ContextMenu is a menu that appears upon user interaction, such as right
 // clicking or pressing Alt+Enter.
@@ -13,6 +17,16 @@ type ContextMenu struct {
 	x, y     int
 	selected func(int, string, rune)
 
+	// The menu this menu is a submenu of, or nil for top-level menus.
+	parentMenu *ContextMenu
+
+	// The submenus of this menu by item, and the currently open submenu.
+	subMenus map[*ListItem]*ContextMenu
+	subMenu  *ContextMenu
+
+	// The function used to set the focus when this menu was shown.
+	setFocus func(Primitive)
+
 	l sync.RWMutex
 }
 
@@ -39,6 +53,21 @@ func (c *ContextMenu) initializeList() {
 		Styles.ContextMenuPaddingBottom,
 		Styles.ContextMenuPaddingLeft,
 		Styles.ContextMenuPaddingRight)
+	c.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		c.l.Lock()
+		defer c.l.Unlock()
+
+		if HitShortcut(event, Keys.MoveRight) {
+			if sub := c.subMenus[c.list.GetCurrentItem()]; sub != nil {
+				c.showSubMenu(sub)
+				return nil
+			}
+		} else if HitShortcut(event, Keys.MoveLeft) && c.parentMenu != nil {
+			c.hide(c.setFocus)
+			return nil
+		}
+		return event
+	})
 }
 
 // ContextMenuList returns the underlying List of the context menu.
@@ -72,6 +101,29 @@ func (c *ContextMenu) AddContextItem(text string, shortcut rune, selected func(i
 	}
 }
 
+// AddSubMenu adds an item which opens a submenu to the context menu. The
+// submenu is returned. Submenus are opened by selecting the item or pressing
+// Right, and closed by pressing Left or Escape. Selecting an item of a submenu
+// closes all menus.
+func (c *ContextMenu) AddSubMenu(text string) *ContextMenu {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	c.initializeList()
+
+	item := NewListItem(text + " " + string(Styles.ContextMenuSubMenuRune))
+	c.list.AddItem(item)
+
+	sub := NewContextMenu(c.list)
+	sub.parentMenu = c
+	sub.initializeList()
+	if c.subMenus == nil {
+		c.subMenus = make(map[*ListItem]*ContextMenu)
+	}
+	c.subMenus[item] = sub
+	return sub
+}
+
 func (c *ContextMenu) wrap(f func(index int)) func() {
 	return func() {
 		f(c.item)
@@ -86,6 +138,8 @@ func (c *ContextMenu) ClearContextMenu() {
 	c.initializeList()
 
 	c.list.Clear()
+	c.subMenus = nil
+	c.subMenu = nil
 }
 
 // SetContextSelectedFunc sets the function which is called when the user
@@ -100,7 +154,7 @@ func (c *ContextMenu) SetContextSelectedFunc(handler func(index int, text string
 }
 
 // ShowContextMenu shows the context menu. Provide -1 for both to position on
-// the selected item, or specify a 	position.
+// the selected item, or specify a position.
 func (c *ContextMenu) ShowContextMenu(item int, x int, y int, setFocus func(Primitive)) {
 	c.l.Lock()
 	defer c.l.Unlock()
@@ -108,6 +162,18 @@ func (c *ContextMenu) ShowContextMenu(item int, x int, y int, setFocus func(Prim
 	c.show(item, x, y, setFocus)
 }
 
+// ShowContextMenuAt shows the context menu with its top-left corner at the
+// provided screen position, e.g. where the mouse was right-clicked. The menu
+// is shifted up and to the left when it would extend beyond the screen. It
+// receives focus and is hidden when Escape is pressed or when the mouse is
+// clicked outside of it.
+func (c *ContextMenu) ShowContextMenuAt(x, y int, setFocus func(Primitive)) {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	c.show(c.item, x, y, setFocus)
+}
+
 // HideContextMenu hides the context menu.
 func (c *ContextMenu) HideContextMenu(setFocus func(Primitive)) {
 	c.l.Lock()
@@ -134,6 +200,7 @@ func (c *ContextMenu) show(item int, x int, y int, setFocus func(Primitive)) {
 	c.open = true
 	c.item = item
 	c.x, c.y = x, y
+	c.setFocus = setFocus
 
 	c.list.Lock()
 	for i, item := range c.list.items {
@@ -147,14 +214,30 @@ func (c *ContextMenu) show(item int, x int, y int, setFocus func(Primitive)) {
 	c.list.SetSelectedFunc(func(index int, item *ListItem) {
 		c.l.Lock()
 
-		// A context item was selected. Close the menu.
-		c.hide(setFocus)
-
-		if c.selected != nil {
+		// A submenu was selected. Open it.
+		if sub := c.subMenus[item]; sub != nil {
+			c.showSubMenu(sub)
 			c.l.Unlock()
-			c.selected(index, string(item.mainText), item.shortcut)
+			return
+		}
+
+		// A context item was selected. Close the menu.
+		root := c
+		if c.parentMenu != nil {
+			for root.parentMenu != nil {
+				root = root.parentMenu
+			}
 		} else {
-			c.l.Unlock()
+			c.hide(setFocus)
+		}
+		selected := c.selected
+		c.l.Unlock()
+
+		if root != c {
+			root.HideContextMenu(setFocus)
+		}
+		if selected != nil {
+			selected(index, string(item.mainText), item.shortcut)
 		}
 	})
 	c.list.SetDoneFunc(func() {
@@ -167,12 +250,146 @@ func (c *ContextMenu) show(item int, x int, y int, setFocus func(Primitive)) {
 	setFocus(c.list)
 }
 
+// showSubMenu shows the provided submenu next to the current item. The caller
+// must hold the lock.
+func (c *ContextMenu) showSubMenu(sub *ContextMenu) {
+	c.closeSubMenu()
+
+	c.subMenu = sub
+
+	sub.l.Lock()
+	defer sub.l.Unlock()
+
+	sub.show(c.item, -1, -1, c.setFocus)
+}
+
+// closeSubMenu closes the open submenus of this menu. The caller must hold
+// the lock.
+func (c *ContextMenu) closeSubMenu() {
+	for sub := c.subMenu; sub != nil; {
+		sub.l.Lock()
+		sub.open = false
+		next := sub.subMenu
+		sub.subMenu = nil
+		sub.l.Unlock()
+
+		sub = next
+	}
+	c.subMenu = nil
+}
+
+// focusedList returns the list of the innermost open menu. The caller must
+// hold the lock.
+func (c *ContextMenu) focusedList() *List {
+	list := c.list
+	for sub := c.subMenu; sub != nil; {
+		sub.l.RLock()
+		if sub.open {
+			list = sub.list
+		}
+		next := sub.subMenu
+		sub.l.RUnlock()
+
+		sub = next
+	}
+	return list
+}
+
+// listAtPoint returns the list of the innermost open menu which contains the
+// provided point, or nil if no menu contains the point.
+func (c *ContextMenu) listAtPoint(x, y int) *List {
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	var list *List
+	if c.list != nil && c.list.InRect(x, y) {
+		list = c.list
+	}
+	if c.subMenu != nil && c.subMenu.ContextMenuVisible() {
+		if subList := c.subMenu.listAtPoint(x, y); subList != nil {
+			list = subList
+		}
+	}
+	return list
+}
+
+// focused returns whether or not this menu or one of its open submenus has
+// focus. The caller must hold the lock.
+func (c *ContextMenu) focused() bool {
+	return c.list.HasFocus() || c.focusedList().HasFocus()
+}
+
+// listSize returns the size required to draw the list of the menu.
+func (c *ContextMenu) listSize() (width int, height int) {
+	c.list.RLock()
+	defer c.list.RUnlock()
+
+	for _, option := range c.list.items {
+		strWidth := TaggedTextWidth(option.mainText)
+		if option.shortcut != 0 {
+			strWidth += 4
+		}
+		if strWidth > width {
+			width = strWidth
+		}
+	}
+	height = len(c.list.items)
+
+	// Add space for borders and padding
+	width += 2 + c.list.paddingLeft + c.list.paddingRight
+	height += 2 + c.list.paddingTop + c.list.paddingBottom
+	return width, height
+}
+
+// drawSubMenus draws the open submenus of this menu. Submenus are positioned
+// next to the current item, on the left side when there isn't enough space
+// on the right side of the screen.
+func (c *ContextMenu) drawSubMenus(screen tcell.Screen) {
+	c.l.RLock()
+	sub := c.subMenu
+	c.l.RUnlock()
+	if sub == nil || !sub.ContextMenuVisible() {
+		return
+	}
+
+	screenWidth, screenHeight := screen.Size()
+	px, _, pwidth, _ := c.list.GetRect()
+	_, py, _, _ := c.list.GetInnerRect()
+	offset, _ := c.list.GetOffset()
+	width, height := sub.listSize()
+
+	x := px + pwidth
+	if x+width > screenWidth {
+		x = px - width
+		if x < 0 {
+			x = 0
+		}
+	}
+	y := py + c.list.GetCurrentItemIndex() - offset - 1
+	if y+height > screenHeight {
+		y = screenHeight - height
+	}
+	if y < 0 {
+		y = 0
+		if height > screenHeight {
+			height = screenHeight
+		}
+	}
+
+	sub.list.SetRect(x, y, width, height)
+	sub.list.Draw(screen)
+	sub.drawSubMenus(screen)
+}
+
 func (c *ContextMenu) hide(setFocus func(Primitive)) {
 	c.initializeList()
 
+	hasFocus := c.focused()
+
 	c.open = false
+	c.closeSubMenu()
 
-	if c.list.HasFocus() {
+	if hasFocus {
 		setFocus(c.parent)
 	}
 }
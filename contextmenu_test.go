@@ -0,0 +1,126 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestContextMenuSubMenu(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.AddItem(NewListItem("Item"))
+	l.AddContextItem("Copy", 0, func(index int) {})
+	sub := l.AddSubMenu("More")
+
+	var selected []string
+	sub.AddContextItem("Delete", 0, func(index int) {
+		selected = append(selected, "item")
+	})
+	sub.SetContextSelectedFunc(func(index int, text string, shortcut rune) {
+		selected = append(selected, text)
+	})
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	l.SetRect(0, 0, 80, 24)
+
+	var focused Primitive
+	var setFocus func(p Primitive)
+	setFocus = func(p Primitive) {
+		if focused != nil {
+			focused.Blur()
+		}
+		focused = p
+		p.Focus(setFocus)
+	}
+	key := func(k tcell.Key) {
+		focused.InputHandler()(tcell.NewEventKey(k, 0, tcell.ModNone), setFocus)
+	}
+
+	setFocus(l)
+	l.ShowContextMenu(0, 75, 2, setFocus)
+	key(tcell.KeyDown)
+
+	// Right opens the submenu.
+	key(tcell.KeyRight)
+	if !sub.ContextMenuVisible() || focused != sub.ContextMenuList() {
+		t.Fatalf("failed to open submenu")
+	}
+
+	// The submenu is positioned on the left when there is not enough space.
+	l.Draw(app.screen)
+	menuX, _, _, _ := l.ContextMenuList().GetRect()
+	subX, subY, subWidth, _ := sub.ContextMenuList().GetRect()
+	if subX+subWidth != menuX || subY != 3 {
+		t.Errorf("failed to position submenu: expected right edge %d y 3, got %d y %d", menuX, subX+subWidth, subY)
+	}
+
+	// Left closes the submenu.
+	key(tcell.KeyLeft)
+	if sub.ContextMenuVisible() || focused != l.ContextMenuList() {
+		t.Fatalf("failed to close submenu")
+	}
+
+	// Selecting a submenu item closes all menus.
+	key(tcell.KeyEnter)
+	if !sub.ContextMenuVisible() {
+		t.Fatalf("failed to open submenu")
+	}
+	key(tcell.KeyEnter)
+	if sub.ContextMenuVisible() || l.ContextMenuVisible() || focused != l {
+		t.Errorf("failed to close menus")
+	}
+	if len(selected) != 2 || selected[0] != "item" || selected[1] != "Delete" {
+		t.Errorf("failed to select submenu item: expected [item Delete], got %v", selected)
+	}
+}
+
+func TestContextMenuShowAt(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.AddItem(NewListItem("Item"))
+	l.AddContextItem("Copy", 0, func(index int) {})
+	l.AddContextItem("Paste", 0, func(index int) {})
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	l.SetRect(0, 0, 40, 10)
+
+	var focused Primitive
+	var setFocus func(p Primitive)
+	setFocus = func(p Primitive) {
+		if focused != nil {
+			focused.Blur()
+		}
+		focused = p
+		p.Focus(setFocus)
+	}
+	setFocus(l)
+
+	// The menu is shifted to fit on the screen.
+	screenWidth, screenHeight := app.screen.Size()
+	l.ShowContextMenuAt(screenWidth-2, screenHeight-1, setFocus)
+	if focused != l.ContextMenuList() {
+		t.Fatalf("failed to focus context menu")
+	}
+	l.Draw(app.screen)
+	x, y, width, height := l.ContextMenuList().GetRect()
+	if x+width != screenWidth || y+height != screenHeight {
+		t.Errorf("failed to position context menu: got %d,%d %dx%d", x, y, width, height)
+	}
+
+	// Clicking outside of the menu hides it.
+	consumed, capture := l.MouseHandler()(MouseLeftDown, tcell.NewEventMouse(50, 5, tcell.Button1, tcell.ModNone), setFocus)
+	if !consumed || capture != nil {
+		t.Errorf("failed to consume click outside of context menu")
+	} else if l.ContextMenuVisible() || focused != l {
+		t.Errorf("failed to hide context menu")
+	}
+}
@@ -76,10 +76,11 @@ cbind: https://code.rocketnine.space/tslocum/cbind
 Bracketed Paste Mode
 
 Bracketed paste mode is enabled by default. It may be disabled by calling
-Application.EnableBracketedPaste before Application.Run. The following demo
-shows how to handle paste events and process pasted text.
-
-tcell bracketed paste demo: https://github.com/gdamore/tcell/blob/master/_demos/mouse.go
+Application.EnableBracketedPaste before Application.Run. Pasted text, as well
+as characters which are received in quick succession in terminals without
+bracketed paste support, is passed as a whole to focused primitives which
+implement PastePrimitive, such as InputField. Other primitives receive a key
+event for each character.
 
 Mouse Support
 
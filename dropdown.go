@@ -1,6 +1,7 @@
 package cview
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 
@@ -80,6 +81,23 @@ type DropDown struct {
 	// The runes typed so far to directly access one of the list items.
 	prefix string
 
+	// Whether typing filters the options while the list is open.
+	searchEnabled bool
+
+	// The text typed so far to filter the options.
+	search string
+
+	// Whether multiple options may be selected.
+	multiSelect bool
+
+	// An optional function which is called when an option is selected or
+	// deselected in multi-select mode.
+	selectionChanged func(index int, option *DropDownOption, selected bool)
+
+	// An optional function which notifies the form this item belongs to when
+	// the value was changed by the user.
+	formChanged func()
+
 	// The list element for the options.
 	list *List
 
@@ -146,6 +164,9 @@ type DropDown struct {
 	// A flag that determines whether the drop down symbol is always drawn.
 	alwaysDrawDropDownSymbol bool
 
+	// The direction in which the list of options opens.
+	openDirection DropDownOpenDirection
+
 	sync.RWMutex
 }
 
@@ -223,6 +244,17 @@ func (d *DropDown) SetAlwaysDrawDropDownSymbol(alwaysDraw bool) {
 	d.alwaysDrawDropDownSymbol = alwaysDraw
 }
 
+// SetOpenDirection sets the direction in which the list of options opens. By
+// default (DropDownOpenAuto), the list opens below the field unless there is
+// more room above it. The list is shortened and scrolls when there is not
+// enough room for all options in the chosen direction.
+func (d *DropDown) SetOpenDirection(direction DropDownOpenDirection) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.openDirection = direction
+}
+
 // SetCurrentOption sets the index of the currently selected option. This may
 // be a negative value to indicate that no option is currently selected. Calling
 // this function will also trigger the "selected" callback (if there is one).
@@ -402,6 +434,92 @@ func (d *DropDown) SetPrefixTextColor(color tcell.Color) {
 	d.prefixTextColor = color
 }
 
+// SetSearchEnabled sets a flag which determines whether typing while the
+// drop-down list is open filters the options to those containing the typed
+// text (ignoring case). Matches are highlighted. When disabled (the default),
+// typing selects the first option starting with the typed text.
+func (d *DropDown) SetSearchEnabled(enabled bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.searchEnabled = enabled
+}
+
+// SetMultiSelect sets a flag which determines whether multiple options may be
+// selected. In multi-select mode, pressing Space or clicking an option toggles
+// whether it is selected, which is shown by a check mark. Pressing Enter closes
+// the drop-down list. The field shows the selected options, or the number of
+// selected options when they don't fit.
+func (d *DropDown) SetMultiSelect(multiSelect bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.multiSelect = multiSelect
+	d.list.SetMultiSelect(multiSelect)
+}
+
+// GetSelectedOptions returns the indices of the options selected in
+// multi-select mode, in ascending order.
+func (d *DropDown) GetSelectedOptions() []int {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.selectedOptions()
+}
+
+func (d *DropDown) selectedOptions() []int {
+	d.list.RLock()
+	items := d.list.items
+	if d.list.unfilteredItems != nil {
+		items = d.list.unfilteredItems
+	}
+	var indices []int
+	for _, item := range items {
+		if item.checked {
+			if index := d.optionIndex(item); index >= 0 {
+				indices = append(indices, index)
+			}
+		}
+	}
+	d.list.RUnlock()
+	return indices
+}
+
+// SetSelectionChangedFunc sets a handler which is called when the user selects
+// or deselects an option in multi-select mode. The handler is provided with
+// the option's index, the option itself and whether it is now selected.
+func (d *DropDown) SetSelectionChangedFunc(handler func(index int, option *DropDownOption, selected bool)) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.selectionChanged = handler
+}
+
+// setFormChangedFunc sets a handler which is called when the value of this
+// drop-down was changed by the user. It is used by Form.
+func (d *DropDown) setFormChangedFunc(handler func()) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.formChanged = handler
+}
+
+// toggleOption toggles whether the option represented by the provided list
+// item is selected in multi-select mode.
+func (d *DropDown) toggleOption(item *ListItem) {
+	index := d.optionIndex(item)
+	if index < 0 || item.disabled {
+		return
+	}
+	item.checked = !item.checked
+	if d.selectionChanged != nil {
+		d.selectionChanged(index, d.options[index], item.checked)
+	}
+	if d.formChanged != nil {
+		d.formChanged()
+	}
+}
+
 // SetFieldWidth sets the screen width of the options area. A value of 0 means
 // extend to as long as the longest option text.
 func (d *DropDown) SetFieldWidth(width int) {
@@ -460,8 +578,45 @@ func (d *DropDown) AddOptions(options ...*DropDownOption) {
 func (d *DropDown) addOptions(options ...*DropDownOption) {
 	d.options = append(d.options, options...)
 	for _, option := range options {
-		d.list.AddItem(NewListItem(d.optionPrefix + option.text + d.optionSuffix))
+		item := NewListItem(d.optionPrefix + option.text + d.optionSuffix)
+		item.SetReference(option)
+		d.list.AddItem(item)
+	}
+}
+
+// optionIndex returns the index of the option represented by the provided
+// list item, or -1 if there is no such option.
+func (d *DropDown) optionIndex(item *ListItem) int {
+	if item == nil {
+		return -1
+	}
+	option, _ := item.GetReference().(*DropDownOption)
+	for index := range d.options {
+		if d.options[index] == option {
+			return index
+		}
 	}
+	return -1
+}
+
+// setSearch filters the options in the drop-down list to those containing the
+// provided text and highlights the matches. An empty text shows all options.
+func (d *DropDown) setSearch(search string) {
+	d.search = search
+
+	d.list.SetFilter("")
+	lowerSearch := strings.ToLower(search)
+	for index, option := range d.options {
+		text := option.text
+		if search != "" {
+			lowerText := strings.ToLower(text)
+			if i := strings.Index(lowerText, lowerSearch); i >= 0 && len(lowerText) == len(text) {
+				text = text[:i] + "[::bu]" + text[i:i+len(search)] + "[::-]" + text[i+len(search):]
+			}
+		}
+		d.list.SetItemText(index, d.optionPrefix+text+d.optionSuffix, "")
+	}
+	d.list.SetFilter(search)
 }
 
 // SetOptionsSimple replaces all current options with the ones provided and installs
@@ -496,6 +651,10 @@ func (d *DropDown) SetOptions(selected func(index int, option *DropDownOption),
 // -1 and nil.
 func (d *DropDown) SetChangedFunc(handler func(index int, option *DropDownOption)) {
 	d.list.SetChangedFunc(func(index int, item *ListItem) {
+		index = d.optionIndex(item)
+		if index < 0 {
+			return
+		}
 		handler(index, d.options[index])
 	})
 }
@@ -581,6 +740,9 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	// What's the longest option text?
 	maxWidth := 0
 	optionWrapWidth := TaggedStringWidth(d.optionPrefix + d.optionSuffix)
+	if d.multiSelect {
+		optionWrapWidth += 4 // Add space for the check mark.
+	}
 	for _, option := range d.options {
 		strWidth := TaggedStringWidth(option.text) + optionWrapWidth
 		if strWidth > maxWidth {
@@ -626,7 +788,18 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	} else {
 		color := fieldTextColor
 		text := d.noSelection
-		if d.currentOption >= 0 && d.currentOption < len(d.options) {
+		if d.multiSelect {
+			if selected := d.selectedOptions(); len(selected) > 0 {
+				texts := make([]string, len(selected))
+				for i, index := range selected {
+					texts[i] = d.options[index].text
+				}
+				text = d.currentOptionPrefix + strings.Join(texts, ", ") + d.currentOptionSuffix
+				if TaggedStringWidth(text) > fieldWidth-3 {
+					text = fmt.Sprintf("%d selected", len(selected))
+				}
+			}
+		} else if d.currentOption >= 0 && d.currentOption < len(d.options) {
 			text = d.currentOptionPrefix + d.options[d.currentOption].text + d.currentOptionSuffix
 		}
 		// Abbreviate text when not fitting
@@ -651,17 +824,32 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	if hasFocus && d.open {
 		// We prefer to drop-down but if there is no space, maybe drop up?
 		lx := x
-		ly := y + 1
 		lheight := len(d.options)
+		if d.search != "" {
+			lheight = d.list.GetItemCount() + 1 // Add space for the search text.
+		}
 		_, sheight := screen.Size()
-		if ly+lheight >= sheight && ly-2 > lheight-ly {
+		above, below := y, sheight-y-1
+		var up bool
+		switch d.openDirection {
+		case DropDownOpenUp:
+			up = true
+		case DropDownOpenDown:
+			up = false
+		default:
+			up = lheight > below && above > below
+		}
+		var ly int
+		if up {
+			if lheight > above {
+				lheight = above
+			}
 			ly = y - lheight
-			if ly < 0 {
-				ly = 0
+		} else {
+			if lheight > below {
+				lheight = below
 			}
-		}
-		if ly+lheight >= sheight {
-			lheight = sheight - ly
+			ly = y + 1
 		}
 		lwidth := maxWidth
 		if d.list.scrollBarVisibility == ScrollBarAlways || (d.list.scrollBarVisibility == ScrollBarAuto && len(d.options) > lheight) {
@@ -687,7 +875,9 @@ func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 			d.prefix = ""
 
 			// If the first key was a letter already, it becomes part of the prefix.
-			if r := event.Rune(); key == tcell.KeyRune && r != ' ' {
+			if r := event.Rune(); key == tcell.KeyRune && r != ' ' && d.searchEnabled {
+				d.setSearch(string(r))
+			} else if key == tcell.KeyRune && r != ' ' {
 				d.prefix += string(r)
 				d.evalPrefix()
 			}
@@ -730,9 +920,18 @@ func (d *DropDown) openList(setFocus func(Primitive)) {
 			return // If we're dragging the mouse, we don't want to trigger any events.
 		}
 
+		// Options are toggled when clicked in multi-select mode.
+		if d.multiSelect {
+			d.toggleOption(item)
+			return
+		}
+
 		// An option was selected. Close the list again.
-		d.currentOption = index
+		d.currentOption = d.optionIndex(item)
 		d.closeList(setFocus)
+		if d.currentOption < 0 {
+			return
+		}
 
 		// Trigger "selected" event.
 		if d.selected != nil {
@@ -741,8 +940,35 @@ func (d *DropDown) openList(setFocus func(Primitive)) {
 		if d.options[d.currentOption].selected != nil {
 			d.options[d.currentOption].selected(d.currentOption, d.options[d.currentOption])
 		}
+		if d.currentOption != optionBefore && d.formChanged != nil {
+			d.formChanged()
+		}
 	})
 	d.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if d.multiSelect {
+			if HitShortcut(event, Keys.Select2) && d.search == "" {
+				d.toggleOption(d.list.GetCurrentItem())
+				return nil
+			} else if HitShortcut(event, Keys.Select) {
+				d.closeList(setFocus)
+				return nil
+			}
+		}
+
+		if d.searchEnabled {
+			switch event.Key() {
+			case tcell.KeyRune:
+				d.setSearch(d.search + string(event.Rune()))
+				return nil
+			case tcell.KeyBackspace, tcell.KeyBackspace2:
+				if len(d.search) > 0 {
+					r := []rune(d.search)
+					d.setSearch(string(r[:len(r)-1]))
+				}
+				return nil
+			}
+		}
+
 		if event.Key() == tcell.KeyRune {
 			d.prefix += string(event.Rune())
 			d.evalPrefix()
@@ -753,9 +979,9 @@ func (d *DropDown) openList(setFocus func(Primitive)) {
 			}
 			d.evalPrefix()
 		} else if event.Key() == tcell.KeyEscape {
+			d.closeList(setFocus)
 			d.currentOption = optionBefore
 			d.list.SetCurrentItem(d.currentOption)
-			d.closeList(setFocus)
 			if d.selected != nil {
 				if d.currentOption > -1 {
 					d.selected(d.currentOption, d.options[d.currentOption])
@@ -775,6 +1001,9 @@ func (d *DropDown) openList(setFocus func(Primitive)) {
 // from it.
 func (d *DropDown) closeList(setFocus func(Primitive)) {
 	d.open = false
+	if d.search != "" {
+		d.setSearch("")
+	}
 	if d.list.HasFocus() {
 		setFocus(d)
 	}
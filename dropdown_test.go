@@ -0,0 +1,140 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDropDownSearch(t *testing.T) {
+	t.Parallel()
+
+	d := NewDropDown()
+	d.SetOptionsSimple(nil, "Apple", "Banana", "Pineapple", "Cherry")
+	d.SetSearchEnabled(true)
+
+	app, err := newTestApp(d)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	setFocus := func(p Primitive) {
+		app.SetFocus(p)
+	}
+
+	d.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone), setFocus)
+	if d.list.GetItemCount() != 3 {
+		t.Errorf("failed to filter options: expected 3 options, got %d", d.list.GetItemCount())
+	}
+
+	for _, r := range "ppl" {
+		d.list.InputHandler()(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), setFocus)
+	}
+	if d.list.GetItemCount() != 2 {
+		t.Errorf("failed to filter options: expected 2 options, got %d", d.list.GetItemCount())
+	}
+
+	d.list.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), setFocus)
+	d.list.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+	index, option := d.GetCurrentOption()
+	if index != 2 || option.GetText() != "Pineapple" {
+		t.Errorf("failed to select filtered option: expected 2, got %d", index)
+	}
+	if d.list.GetItemCount() != 4 {
+		t.Errorf("failed to discard search: expected 4 options, got %d", d.list.GetItemCount())
+	} else if d.list.GetItem(2).GetMainText() != "Pineapple" {
+		t.Errorf("failed to discard search highlighting: got %s", d.list.GetItem(2).GetMainText())
+	}
+}
+
+func TestDropDownMultiSelect(t *testing.T) {
+	t.Parallel()
+
+	d := NewDropDown()
+	d.SetOptionsSimple(nil, "Red", "Green", "Blue")
+	d.SetMultiSelect(true)
+
+	var toggled []int
+	d.SetSelectionChangedFunc(func(index int, option *DropDownOption, selected bool) {
+		if !selected {
+			index = -index
+		}
+		toggled = append(toggled, index)
+	})
+
+	app, err := newTestApp(d)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	setFocus := func(p Primitive) {
+		app.SetFocus(p)
+	}
+
+	d.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+	listHandler := d.list.InputHandler()
+	listHandler(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), setFocus)
+	listHandler(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), setFocus)
+	listHandler(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), setFocus)
+	listHandler(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), setFocus)
+	listHandler(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), setFocus)
+	listHandler(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), setFocus)
+	listHandler(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), setFocus)
+	listHandler(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+
+	selected := d.GetSelectedOptions()
+	if len(selected) != 2 || selected[0] != 0 || selected[1] != 2 {
+		t.Errorf("failed to select options: expected [0 2], got %v", selected)
+	}
+	if len(toggled) != 4 || toggled[2] != 1 || toggled[3] != -1 {
+		t.Errorf("failed to notify selection changes: got %v", toggled)
+	}
+
+	d.open = false
+	d.SetFieldWidth(20)
+	d.SetRect(0, 0, 20, 1)
+	d.Draw(app.screen)
+	var text []rune
+	for x := 0; x < 9; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 0)
+		text = append(text, r)
+	}
+	if string(text) != "Red, Blue" {
+		t.Errorf("failed to draw selected options: expected Red, Blue, got %s", string(text))
+	}
+}
+
+func TestDropDownOpenDirection(t *testing.T) {
+	t.Parallel()
+
+	d := NewDropDown()
+	d.SetOptionsSimple(nil, "1", "2", "3", "4", "5", "6", "7", "8", "9", "10")
+
+	app, err := newTestApp(d)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	d.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {
+		app.SetFocus(p)
+	})
+
+	tests := []struct {
+		direction DropDownOpenDirection
+		y         int
+		listY     int
+		height    int
+	}{
+		{DropDownOpenAuto, 2, 3, 10},
+		{DropDownOpenAuto, 20, 10, 10},
+		{DropDownOpenDown, 20, 21, 3},
+		{DropDownOpenUp, 5, 0, 5},
+	}
+	for _, test := range tests {
+		d.SetOpenDirection(test.direction)
+		d.SetRect(0, test.y, 20, 1)
+		d.Draw(app.screen)
+
+		_, y, _, height := d.list.GetRect()
+		if y != test.listY || height != test.height {
+			t.Errorf("failed to open list in direction %d at %d: expected y %d height %d, got y %d height %d", test.direction, test.y, test.listY, test.height, y, height)
+		}
+	}
+}
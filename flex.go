@@ -18,6 +18,8 @@ type flexItem struct {
 	FixedSize  int       // The item's fixed size which may not be changed, 0 if it has no fixed size.
 	Proportion int       // The item's proportion.
 	Focus      bool      // Whether or not this item attracts the layout's focus.
+	MinSize    int       // The item's minimum size when it has no fixed size, 0 if it has no minimum size.
+	MaxSize    int       // The item's maximum size when it has no fixed size, 0 if it has no maximum size.
 }
 
 // Flex is a basic implementation of the Flexbox layout. The contained
@@ -33,10 +35,17 @@ type Flex struct {
 	// FlexRow or FlexColumn.
 	direction int
 
+	// The number of cells between adjacent items.
+	gap int
+
 	// If set to true, Flex will use the entire screen as its available space
 	// instead its box dimensions.
 	fullScreen bool
 
+	// The items listed first when moving the focus with Tab and Backtab. Nil
+	// if the focus is not moved by the Flex.
+	focusOrder []Primitive
+
 	sync.RWMutex
 }
 
@@ -76,6 +85,20 @@ func (f *Flex) SetDirection(direction int) {
 	f.direction = direction
 }
 
+// SetGap sets the number of blank cells inserted between adjacent items along
+// the direction in which they are distributed. No space is added before the
+// first item or after the last item. Fixed-size items keep their size, the
+// space is taken from proportional items.
+func (f *Flex) SetGap(gap int) {
+	f.Lock()
+	defer f.Unlock()
+
+	if gap < 0 {
+		gap = 0
+	}
+	f.gap = gap
+}
+
 // SetFullScreen sets the flag which, when true, causes the flex layout to use
 // the entire screen space instead of whatever size it is currently assigned to.
 func (f *Flex) SetFullScreen(fullScreen bool) {
@@ -110,18 +133,39 @@ func (f *Flex) AddItem(item Primitive, fixedSize, proportion int, focus bool) {
 	f.items = append(f.items, &flexItem{Item: item, FixedSize: fixedSize, Proportion: proportion, Focus: focus})
 }
 
-// AddItemAtIndex adds an item to the flex at a given index.
-// For more information see AddItem.
+// AddItemAtIndex adds an item to the flex at a given index. Out of range
+// indices are clamped to the beginning/end. For more information see AddItem.
 func (f *Flex) AddItemAtIndex(index int, item Primitive, fixedSize, proportion int, focus bool) {
 	f.Lock()
 	defer f.Unlock()
+
+	if item == nil {
+		item = NewBox()
+		item.SetVisible(false)
+	}
 	newItem := &flexItem{Item: item, FixedSize: fixedSize, Proportion: proportion, Focus: focus}
 
-	if index == 0 {
-		f.items = append([]*flexItem{newItem}, f.items...)
-	} else {
-		f.items = append(f.items[:index], append([]*flexItem{newItem}, f.items[index:]...)...)
+	if index < 0 {
+		index = 0
+	} else if index > len(f.items) {
+		index = len(f.items)
 	}
+
+	// Build a new slice to avoid overwriting the backing array of f.items.
+	items := make([]*flexItem, 0, len(f.items)+1)
+	items = append(items, f.items[:index]...)
+	items = append(items, newItem)
+	items = append(items, f.items[index:]...)
+	f.items = items
+}
+
+// GetItemIndex returns the index of the first item with the given primitive,
+// or -1 if the primitive is not found.
+func (f *Flex) GetItemIndex(p Primitive) int {
+	f.RLock()
+	defer f.RUnlock()
+
+	return f.itemIndex(p)
 }
 
 // RemoveItem removes all items for the given primitive from the container,
@@ -137,6 +181,60 @@ func (f *Flex) RemoveItem(p Primitive) {
 	}
 }
 
+// MoveItem moves the first item with the given primitive to a new index,
+// keeping the order of the remaining items intact. Out of range indices are
+// clamped to the beginning/end. Nothing happens if the primitive is not found.
+func (f *Flex) MoveItem(p Primitive, newIndex int) {
+	f.Lock()
+	defer f.Unlock()
+
+	index := -1
+	for i, item := range f.items {
+		if item.Item == p {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return
+	}
+
+	if newIndex < 0 {
+		newIndex = 0
+	} else if newIndex > len(f.items)-1 {
+		newIndex = len(f.items) - 1
+	}
+
+	item := f.items[index]
+	if newIndex < index {
+		copy(f.items[newIndex+1:index+1], f.items[newIndex:index])
+	} else {
+		copy(f.items[index:newIndex], f.items[index+1:newIndex+1])
+	}
+	f.items[newIndex] = item
+}
+
+// SwapItems swaps the positions of the first items with the given primitives.
+// Nothing happens if either primitive is not found.
+func (f *Flex) SwapItems(a, b Primitive) {
+	f.Lock()
+	defer f.Unlock()
+
+	indexA, indexB := -1, -1
+	for i, item := range f.items {
+		if indexA == -1 && item.Item == a {
+			indexA = i
+		} else if indexB == -1 && item.Item == b {
+			indexB = i
+		}
+	}
+	if indexA == -1 || indexB == -1 {
+		return
+	}
+
+	f.items[indexA], f.items[indexB] = f.items[indexB], f.items[indexA]
+}
+
 // ResizeItem sets a new size for the item(s) with the given primitive. If there
 // are multiple Flex items with the same primitive, they will all receive the
 // same size. For details regarding the size parameters, see AddItem().
@@ -152,6 +250,103 @@ func (f *Flex) ResizeItem(p Primitive, fixedSize, proportion int) {
 	}
 }
 
+// SetItemMinSize sets the minimum size of the item(s) with the given primitive.
+// Items which have a fixed size are not affected. When the available space is
+// distributed, proportional items are never made smaller than their minimum
+// size. The remaining space is distributed among the other items. If there is
+// not enough space to satisfy all fixed and minimum sizes, the items which do
+// not fit are clipped. A value of 0 removes the minimum size.
+func (f *Flex) SetItemMinSize(p Primitive, minSize int) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, item := range f.items {
+		if item.Item == p {
+			item.MinSize = minSize
+		}
+	}
+}
+
+// SetItemMaxSize sets the maximum size of the item(s) with the given primitive.
+// Items which have a fixed size are not affected. When the available space is
+// distributed, proportional items are never made larger than their maximum
+// size. The remaining space is distributed among the other items. A value of 0
+// removes the maximum size.
+func (f *Flex) SetItemMaxSize(p Primitive, maxSize int) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, item := range f.items {
+		if item.Item == p {
+			item.MaxSize = maxSize
+		}
+	}
+}
+
+// itemSizes distributes the given amount of space among the items and returns
+// the size of each item.
+func (f *Flex) itemSizes(distSize int) []int {
+	sizes := make([]int, len(f.items))
+	clamped := make([]bool, len(f.items))
+	for i, item := range f.items {
+		if item.FixedSize > 0 {
+			sizes[i] = item.FixedSize
+			clamped[i] = true
+			distSize -= item.FixedSize
+		}
+	}
+
+	// Distribute space proportionally. When an item violates its minimum or
+	// maximum size, it is clamped and the space is distributed again among
+	// the remaining items.
+	for {
+		var proportionSum int
+		for i, item := range f.items {
+			if !clamped[i] {
+				proportionSum += item.Proportion
+			}
+		}
+
+		remaining := distSize
+		var changed bool
+		for i, item := range f.items {
+			if clamped[i] {
+				continue
+			}
+
+			size := 0
+			if proportionSum > 0 {
+				size = remaining * item.Proportion / proportionSum
+				remaining -= size
+				proportionSum -= item.Proportion
+			}
+
+			if item.MinSize > 0 && size < item.MinSize {
+				size = item.MinSize
+			} else if item.MaxSize > 0 && size > item.MaxSize {
+				size = item.MaxSize
+			} else {
+				sizes[i] = size
+				continue
+			}
+			sizes[i] = size
+			clamped[i] = true
+			distSize -= size
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+
+	for i := range sizes {
+		if sizes[i] < 0 {
+			sizes[i] = 0
+		}
+	}
+	return sizes
+}
+
 // Draw draws this primitive onto the screen.
 func (f *Flex) Draw(screen tcell.Screen) {
 	if !f.GetVisible() {
@@ -173,32 +368,33 @@ func (f *Flex) Draw(screen tcell.Screen) {
 
 	// How much space can we distribute?
 	x, y, width, height := f.GetInnerRect()
-	var proportionSum int
 	distSize := width
 	if f.direction == FlexRow {
 		distSize = height
 	}
-	for _, item := range f.items {
-		if item.FixedSize > 0 {
-			distSize -= item.FixedSize
-		} else {
-			proportionSum += item.Proportion
-		}
+	if len(f.items) > 1 {
+		distSize -= f.gap * (len(f.items) - 1)
 	}
+	sizes := f.itemSizes(distSize)
 
 	// Calculate positions and draw items.
 	pos := x
 	if f.direction == FlexRow {
 		pos = y
 	}
-	for _, item := range f.items {
-		size := item.FixedSize
-		if size <= 0 {
-			if proportionSum > 0 {
-				size = distSize * item.Proportion / proportionSum
-				distSize -= size
-				proportionSum -= item.Proportion
-			} else {
+	end := pos + width
+	if f.direction == FlexRow {
+		end = pos + height
+	}
+	for i, item := range f.items {
+		if i > 0 {
+			pos += f.gap
+		}
+		size := sizes[i]
+		if pos+size > end {
+			// Clip items which do not fit.
+			size = end - pos
+			if size < 0 {
 				size = 0
 			}
 		}
@@ -249,6 +445,102 @@ func (f *Flex) HasFocus() bool {
 	return false
 }
 
+// SetFocusOrder enables moving the focus between the items of the Flex with
+// Keys.MoveNextField (Tab) and Keys.MovePreviousField (Backtab), starting
+// with the provided items in the provided order. Items which are not listed
+// follow in layout order. Hidden items are skipped and the focus wraps around.
+// These keys are then not passed to the focused item. Calling SetFocusOrder
+// without items disables moving the focus.
+func (f *Flex) SetFocusOrder(items ...Primitive) {
+	f.Lock()
+	defer f.Unlock()
+
+	if len(items) == 0 {
+		f.focusOrder = nil
+		return
+	}
+	f.focusOrder = items
+}
+
+// focusCycle returns the items of the Flex in the order in which the focus
+// moves between them, or nil if the focus is not moved by the Flex. The
+// caller must hold the lock.
+func (f *Flex) focusCycle() []Primitive {
+	if f.focusOrder == nil {
+		return nil
+	}
+
+	var cycle []Primitive
+	listed := make(map[Primitive]bool)
+	for _, p := range f.focusOrder {
+		if p != nil && !listed[p] && f.itemIndex(p) >= 0 {
+			cycle = append(cycle, p)
+			listed[p] = true
+		}
+	}
+	for _, item := range f.items {
+		if item.Item != nil && !listed[item.Item] {
+			cycle = append(cycle, item.Item)
+			listed[item.Item] = true
+		}
+	}
+	return cycle
+}
+
+// itemIndex returns the index of the first item for the provided primitive,
+// or -1 if the primitive is not an item. The caller must hold the lock.
+func (f *Flex) itemIndex(p Primitive) int {
+	for index, item := range f.items {
+		if item.Item == p {
+			return index
+		}
+	}
+	return -1
+}
+
+// interceptKey passes a key event on to the focused item. When a focus order
+// is set, the focus is moved between items.
+func (f *Flex) interceptKey(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	f.RLock()
+	items := make([]Primitive, len(f.items))
+	for i, item := range f.items {
+		items[i] = item.Item
+	}
+	cycle := f.focusCycle()
+	f.RUnlock()
+
+	if interceptKey(event, setFocus, items...) {
+		return true
+	}
+
+	step := 1
+	if HitShortcut(event, Keys.MovePreviousField) {
+		step = -1
+	} else if !HitShortcut(event, Keys.MoveNextField) {
+		return false
+	}
+
+	current := -1
+	for index, p := range cycle {
+		if p.GetFocusable().HasFocus() {
+			current = index
+			break
+		}
+	}
+	if current < 0 {
+		return false
+	}
+	n := len(cycle)
+	for i := 1; i < n; i++ {
+		next := cycle[((current+step*i)%n+n)%n]
+		if next.GetVisible() {
+			setFocus(next)
+			return true
+		}
+	}
+	return true
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (f *Flex) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
@@ -0,0 +1,289 @@
+package cview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFlexAddItemAtIndex(t *testing.T) {
+	t.Parallel()
+
+	f := NewFlex()
+	a, b, c := NewBox(), NewBox(), NewBox()
+	f.AddItem(a, 0, 1, false)
+	f.AddItem(b, 0, 1, false)
+
+	// Insert at beginning
+
+	first := NewBox()
+	f.AddItemAtIndex(0, first, 0, 1, false)
+	expectFlexItems(t, f, first, a, b)
+
+	// Insert in middle
+
+	f.AddItemAtIndex(2, c, 0, 1, false)
+	expectFlexItems(t, f, first, a, c, b)
+
+	// Insert at exact end
+
+	end := NewBox()
+	f.AddItemAtIndex(4, end, 0, 1, false)
+	expectFlexItems(t, f, first, a, c, b, end)
+
+	// Insert past end
+
+	past := NewBox()
+	f.AddItemAtIndex(100, past, 0, 1, false)
+	expectFlexItems(t, f, first, a, c, b, end, past)
+
+	// Insert before beginning
+
+	before := NewBox()
+	f.AddItemAtIndex(-1, before, 0, 1, false)
+	expectFlexItems(t, f, before, first, a, c, b, end, past)
+
+	// Look up indices
+
+	if index := f.GetItemIndex(c); index != 3 {
+		t.Errorf("failed to get item index: expected 3, got %d", index)
+	}
+	if index := f.GetItemIndex(NewBox()); index != -1 {
+		t.Errorf("failed to get index of missing item: expected -1, got %d", index)
+	}
+
+	// Insert into empty flex
+
+	empty := NewFlex()
+	if index := empty.GetItemIndex(a); index != -1 {
+		t.Errorf("failed to get index in empty Flex: expected -1, got %d", index)
+	}
+	empty.AddItemAtIndex(5, a, 0, 1, false)
+	expectFlexItems(t, empty, a)
+	if index := empty.GetItemIndex(a); index != 0 {
+		t.Errorf("failed to get item index: expected 0, got %d", index)
+	}
+}
+
+func TestFlexAddItemAtIndexAliasing(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := NewBox(), NewBox(), NewBox()
+
+	f := NewFlex()
+	f.items = make([]*flexItem, 0, 8)
+	f.AddItem(a, 0, 1, false)
+	f.AddItem(b, 0, 1, false)
+
+	original := f.items
+	f.AddItemAtIndex(1, c, 0, 1, false)
+	expectFlexItems(t, f, a, c, b)
+
+	if original[1].Item != b {
+		t.Errorf("failed to insert Flex item: original backing array was modified")
+	}
+}
+
+func expectFlexItems(t *testing.T, f *Flex, expected ...Primitive) {
+	t.Helper()
+
+	if len(f.items) != len(expected) {
+		t.Fatalf("failed to insert Flex item: expected %d items, got %d", len(expected), len(f.items))
+	}
+	for i, item := range f.items {
+		if item.Item != expected[i] {
+			t.Errorf("failed to insert Flex item: unexpected item at index %d", i)
+		}
+	}
+}
+
+func TestFlexItemMinMaxSize(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := NewBox(), NewBox(), NewBox()
+
+	f := NewFlex()
+	f.AddItem(a, 0, 1, false)
+	f.AddItem(b, 0, 1, false)
+	f.AddItem(c, 0, 1, false)
+	f.SetRect(0, 0, 30, 10)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	f.Draw(app.screen)
+	expectFlexWidths(t, 10, 10, 10, a, b, c)
+
+	// Minimum size
+
+	f.SetItemMinSize(a, 16)
+	f.Draw(app.screen)
+	expectFlexWidths(t, 16, 7, 7, a, b, c)
+
+	// Maximum size
+
+	f.SetItemMinSize(a, 0)
+	f.SetItemMaxSize(b, 4)
+	f.Draw(app.screen)
+	expectFlexWidths(t, 13, 4, 13, a, b, c)
+
+	// Unsatisfiable constraints are clipped
+
+	f.SetItemMaxSize(b, 0)
+	f.SetItemMinSize(a, 20)
+	f.SetItemMinSize(b, 20)
+	f.Draw(app.screen)
+	expectFlexWidths(t, 20, 10, 0, a, b, c)
+}
+
+func expectFlexWidths(t *testing.T, wa, wb, wc int, a, b, c Primitive) {
+	t.Helper()
+
+	for i, item := range []struct {
+		p        Primitive
+		expected int
+	}{{a, wa}, {b, wb}, {c, wc}} {
+		if _, _, width, _ := item.p.GetRect(); width != item.expected {
+			t.Errorf("failed to draw Flex: incorrect width of item %d: expected %d, got %d", i, item.expected, width)
+		}
+	}
+}
+
+func TestFlexGap(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := NewBox(), NewBox(), NewBox()
+
+	f := NewFlex()
+	f.SetGap(2)
+	f.AddItem(a, 0, 1, false)
+	f.AddItem(b, 4, 0, false)
+	f.AddItem(c, 0, 1, false)
+	f.SetRect(0, 0, 30, 10)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	f.Draw(app.screen)
+	expectFlexWidths(t, 11, 4, 11, a, b, c)
+
+	for i, item := range []struct {
+		p        Primitive
+		expected int
+	}{{a, 0}, {b, 13}, {c, 19}} {
+		if x, _, _, _ := item.p.GetRect(); x != item.expected {
+			t.Errorf("failed to draw Flex: incorrect position of item %d: expected %d, got %d", i, item.expected, x)
+		}
+	}
+}
+
+func TestFlexMoveItem(t *testing.T) {
+	t.Parallel()
+
+	a, b, c, d := NewBox(), NewBox(), NewBox(), NewBox()
+
+	f := NewFlex()
+	f.AddItem(a, 0, 1, false)
+	f.AddItem(b, 0, 1, false)
+	f.AddItem(c, 0, 1, false)
+
+	f.MoveItem(a, 2)
+	expectFlexItems(t, f, b, c, a)
+
+	f.MoveItem(a, 0)
+	expectFlexItems(t, f, a, b, c)
+
+	f.MoveItem(c, -5)
+	expectFlexItems(t, f, c, a, b)
+
+	f.MoveItem(c, 10)
+	expectFlexItems(t, f, a, b, c)
+
+	f.MoveItem(d, 0)
+	expectFlexItems(t, f, a, b, c)
+
+	f.SwapItems(a, c)
+	expectFlexItems(t, f, c, b, a)
+
+	f.SwapItems(a, d)
+	expectFlexItems(t, f, c, b, a)
+}
+
+func TestFlexFocusOrder(t *testing.T) {
+	t.Parallel()
+
+	focused := make(chan string, 10)
+	boxes := make(map[string]*Box)
+	for _, name := range []string{"main", "footer", "hidden", "sidebar"} {
+		name := name
+		boxes[name] = NewBox()
+		boxes[name].SetFocusFunc(func() {
+			focused <- name
+		})
+	}
+	boxes["hidden"].SetVisible(false)
+
+	f := NewFlex()
+	f.AddItem(boxes["main"], 0, 1, true)
+	f.AddItem(boxes["footer"], 1, 0, false)
+	f.AddItem(boxes["hidden"], 1, 0, false)
+	f.AddItem(boxes["sidebar"], 10, 0, false)
+
+	root := NewFrame(f)
+
+	app, screen, done := runTestApp(root)
+	defer func() {
+		app.Stop()
+		<-done
+	}()
+	if name := <-focused; name != "main" {
+		t.Fatalf("failed to focus first item: got %s", name)
+	}
+
+	expect := func(key tcell.Key, expected string) {
+		t.Helper()
+		screen.InjectKey(key, 0, tcell.ModNone)
+		select {
+		case name := <-focused:
+			if name != expected {
+				t.Errorf("failed to move focus in focus order: expected %s, got %s", expected, name)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("failed to move focus in focus order: expected %s", expected)
+		}
+	}
+
+	// Tab is passed on to the focused item without a focus order.
+	keys := make(chan string, 1)
+	boxes["main"].SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		keys <- event.Name()
+		return event
+	})
+	screen.InjectKey(tcell.KeyTab, 0, tcell.ModNone)
+	select {
+	case name := <-keys:
+		if name != "Tab" {
+			t.Errorf("failed to pass Tab to focused item: got %s", name)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("failed to pass Tab to focused item")
+	}
+	select {
+	case name := <-focused:
+		t.Errorf("failed to pass Tab to focused item: focused %s", name)
+	default:
+	}
+	boxes["main"].SetInputCapture(nil)
+
+	f.SetFocusOrder(boxes["sidebar"], boxes["main"])
+	for _, name := range []string{"footer", "sidebar", "main"} {
+		expect(tcell.KeyTab, name)
+	}
+	expect(tcell.KeyBacktab, "sidebar")
+	expect(tcell.KeyBacktab, "footer")
+}
@@ -25,6 +25,7 @@ type FormItemAttributes struct {
 	FieldBackgroundColorFocused tcell.Color
 	FieldTextColor              tcell.Color
 	FieldTextColorFocused       tcell.Color
+	ValidationErrorColor        tcell.Color
 
 	FinishedFunc func(key tcell.Key)
 }
@@ -103,6 +104,11 @@ type Form struct {
 	// focus so that the last element that had focus keeps it.
 	focusedElement int
 
+	// The indices of the form elements in the order in which they are
+	// navigated, counted like focusedElement. Elements which are not listed
+	// follow in their natural order.
+	tabOrder []int
+
 	// Whether or not navigating the form will wrap around.
 	wrapAround bool
 
@@ -136,12 +142,25 @@ type Form struct {
 	// The color of the button text when focused.
 	buttonTextColorFocused tcell.Color
 
+	// The text color of validation errors.
+	validationErrorColor tcell.Color
+
 	// An optional function which is called when the user hits Escape.
 	cancel func()
 
+	// An optional function which is called when the value of a form item was
+	// changed by the user.
+	changed func(item FormItem)
+
 	sync.RWMutex
 }
 
+// formChangeNotifier is implemented by form items which notify the form they
+// belong to when their value was changed by the user.
+type formChangeNotifier interface {
+	setFormChangedFunc(handler func())
+}
+
 // NewForm returns a new form.
 func NewForm() *Form {
 	box := NewBox()
@@ -160,6 +179,7 @@ func NewForm() *Form {
 		buttonTextColor:              Styles.PrimaryTextColor,
 		buttonTextColorFocused:       Styles.PrimaryTextColor,
 		labelColorFocused:            ColorUnset,
+		validationErrorColor:         tcell.ColorRed.TrueColor(),
 	}
 
 	f.focus = f
@@ -179,7 +199,8 @@ func (f *Form) SetItemPadding(padding int) {
 // SetHorizontal sets the direction the form elements are laid out. If set to
 // true, instead of positioning them from top to bottom (the default), they are
 // positioned from left to right, moving into the next row if there is not
-// enough space.
+// enough space for an item's label and field. Items are separated by the
+// number of cells set with SetItemPadding, and buttons follow the last item.
 func (f *Form) SetHorizontal(horizontal bool) {
 	f.Lock()
 	defer f.Unlock()
@@ -235,6 +256,15 @@ func (f *Form) SetFieldTextColorFocused(color tcell.Color) {
 	f.fieldTextColorFocused = color
 }
 
+// SetValidationErrorColor sets the text color of validation errors shown below
+// form items.
+func (f *Form) SetValidationErrorColor(color tcell.Color) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.validationErrorColor = color
+}
+
 // SetButtonsAlign sets how the buttons align horizontally, one of AlignLeft
 // (the default), AlignCenter, and AlignRight. This is only
 func (f *Form) SetButtonsAlign(align int) {
@@ -292,6 +322,27 @@ func (f *Form) SetFocus(index int) {
 	}
 }
 
+// SetTabOrder sets the order in which the form elements are navigated with
+// Tab and Enter, given as element indices which count non-button items first
+// and buttons last (see SetFocus). Shift-Tab navigates in reverse order.
+// Elements which are not listed follow the listed elements in their natural
+// order, e.g. SetTabOrder(2, 0) with two items and one button results in the
+// button, the first item and then the second item. Invalid and duplicate
+// indices are ignored. Calling SetTabOrder without indices restores the
+// natural order.
+//
+// The focus is shifted to the first element of the new order. Note that this
+// is only used when the form itself receives focus.
+func (f *Form) SetTabOrder(indices ...int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.tabOrder = indices
+	if order := f.elementOrder(); len(order) > 0 {
+		f.focusedElement = order[0]
+	}
+}
+
 // AddInputField adds an input field to the form. It has a label, an optional
 // initial value, a field width (a value of 0 extends it as far as possible),
 // an optional accept function to validate the item's value (set to nil to
@@ -308,7 +359,7 @@ func (f *Form) AddInputField(label, value string, fieldWidth int, accept func(te
 	inputField.SetAcceptanceFunc(accept)
 	inputField.SetChangedFunc(changed)
 
-	f.items = append(f.items, inputField)
+	f.addItem(inputField)
 }
 
 // AddPasswordField adds a password field to the form. This is similar to an
@@ -332,7 +383,7 @@ func (f *Form) AddPasswordField(label, value string, fieldWidth int, mask rune,
 	passwordField.SetMaskCharacter(mask)
 	passwordField.SetChangedFunc(changed)
 
-	f.items = append(f.items, passwordField)
+	f.addItem(passwordField)
 }
 
 // AddDropDownSimple adds a drop-down element to the form. It has a label, options,
@@ -348,7 +399,7 @@ func (f *Form) AddDropDownSimple(label string, initialOption int, selected func(
 	dd.SetOptionsSimple(selected, options...)
 	dd.SetCurrentOption(initialOption)
 
-	f.items = append(f.items, dd)
+	f.addItem(dd)
 }
 
 // AddDropDown adds a drop-down element to the form. It has a label, options,
@@ -364,7 +415,7 @@ func (f *Form) AddDropDown(label string, initialOption int, selected func(index
 	dd.SetOptions(selected, options...)
 	dd.SetCurrentOption(initialOption)
 
-	f.items = append(f.items, dd)
+	f.addItem(dd)
 }
 
 // AddCheckBox adds a checkbox to the form. It has a label, a message, an
@@ -380,7 +431,7 @@ func (f *Form) AddCheckBox(label string, message string, checked bool, changed f
 	c.SetChecked(checked)
 	c.SetChangedFunc(changed)
 
-	f.items = append(f.items, c)
+	f.addItem(c)
 }
 
 // AddSlider adds a slider to the form. It has a label, an initial value, a
@@ -398,7 +449,7 @@ func (f *Form) AddSlider(label string, current, max, increment int, changed func
 	s.SetIncrement(increment)
 	s.SetChangedFunc(changed)
 
-	f.items = append(f.items, s)
+	f.addItem(s)
 }
 
 // AddButton adds a new button to the form. The "selected" function is called
@@ -412,6 +463,37 @@ func (f *Form) AddButton(label string, selected func()) {
 	f.buttons = append(f.buttons, button)
 }
 
+// AddSubmitButton adds a new button to the form which validates all form items
+// when selected. The "submitted" function is only called when all form items
+// are valid. Otherwise, validation errors are shown below the invalid items.
+func (f *Form) AddSubmitButton(label string, submitted func()) {
+	f.AddButton(label, func() {
+		if len(f.Validate()) == 0 && submitted != nil {
+			submitted()
+		}
+	})
+}
+
+// Validate validates all form items which support validation (e.g. InputField
+// with a validation function set) and returns the resulting errors. An empty
+// slice is returned when all form items are valid.
+func (f *Form) Validate() []error {
+	f.RLock()
+	items := make([]FormItem, len(f.items))
+	copy(items, f.items)
+	f.RUnlock()
+
+	var errs []error
+	for _, item := range items {
+		if validator, ok := item.(interface{ Validate() error }); ok {
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
 // GetButton returns the button at the specified 0-based index. Note that
 // buttons have been specially prepared for this form and modifying some of
 // their attributes may have unintended side effects.
@@ -460,6 +542,7 @@ func (f *Form) Clear(includeButtons bool) {
 	f.Lock()
 	defer f.Unlock()
 
+	removeItems(f.items)
 	f.items = nil
 	if includeButtons {
 		f.buttons = nil
@@ -493,7 +576,34 @@ func (f *Form) AddFormItem(item FormItem) {
 		panic("Invalid FormItem")
 	}
 
+	f.addItem(item)
+}
+
+// addItem adds a form item and hooks it up to the form's changed handler. The
+// caller must hold the lock.
+func (f *Form) addItem(item FormItem) {
 	f.items = append(f.items, item)
+	if notifier, ok := item.(formChangeNotifier); ok {
+		notifier.setFormChangedFunc(func() {
+			f.RLock()
+			changed := f.changed
+			f.RUnlock()
+
+			if changed != nil {
+				changed(item)
+			}
+		})
+	}
+}
+
+// removeItems detaches the provided form items from the form's changed
+// handler.
+func removeItems(items []FormItem) {
+	for _, item := range items {
+		if notifier, ok := item.(formChangeNotifier); ok {
+			notifier.setFormChangedFunc(nil)
+		}
+	}
 }
 
 // GetFormItemCount returns the number of items in the form (not including the
@@ -536,6 +646,7 @@ func (f *Form) RemoveFormItem(index int) {
 	f.Lock()
 	defer f.Unlock()
 
+	removeItems(f.items[index : index+1])
 	f.items = append(f.items[:index], f.items[index+1:]...)
 }
 
@@ -606,6 +717,17 @@ func (f *Form) SetCancelFunc(callback func()) {
 	f.cancel = callback
 }
 
+// SetChangedFunc sets a handler which is called whenever the value of one of
+// the form's items is changed by the user, e.g. to track unsaved changes. The
+// handler receives the item which was changed. Input fields, checkboxes,
+// drop-downs and sliders are supported.
+func (f *Form) SetChangedFunc(handler func(item FormItem)) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.changed = handler
+}
+
 // GetAttributes returns the current attribute settings of a form.
 func (f *Form) GetAttributes() *FormItemAttributes {
 	f.Lock()
@@ -620,6 +742,7 @@ func (f *Form) getAttributes() *FormItemAttributes {
 		LabelColor:           f.labelColor,
 		FieldBackgroundColor: f.fieldBackgroundColor,
 		FieldTextColor:       f.fieldTextColor,
+		ValidationErrorColor: f.validationErrorColor,
 	}
 	if f.labelColorFocused == ColorUnset {
 		attrs.LabelColorFocused = f.labelColor
@@ -697,7 +820,7 @@ func (f *Form) Draw(screen tcell.Screen) {
 		}
 
 		// Advance to next line if there is no space.
-		if f.horizontal && x+labelWidth+1 >= rightLimit {
+		if f.horizontal && x > startX && x+itemWidth > rightLimit {
 			x = startX
 			y += 2
 		}
@@ -732,7 +855,7 @@ func (f *Form) Draw(screen tcell.Screen) {
 	buttonWidths := make([]int, len(f.buttons))
 	buttonsWidth := 0
 	for index, button := range f.buttons {
-		w := TaggedStringWidth(button.GetLabel()) + 4
+		w := button.contentWidth() + 4
 		buttonWidths[index] = w
 		buttonsWidth += w + 1
 	}
@@ -847,43 +970,89 @@ func (f *Form) Draw(screen tcell.Screen) {
 	}
 }
 
-func (f *Form) updateFocusedElement(decreasing bool) {
+// elementOrder returns the indices of the form elements in navigation order.
+// The caller must hold the lock.
+func (f *Form) elementOrder() []int {
+	count := len(f.items) + len(f.buttons)
+	order := make([]int, 0, count)
+	listed := make(map[int]bool)
+	for _, index := range f.tabOrder {
+		if index >= 0 && index < count && !listed[index] {
+			order = append(order, index)
+			listed[index] = true
+		}
+	}
+	for index := 0; index < count; index++ {
+		if !listed[index] {
+			order = append(order, index)
+		}
+	}
+	return order
+}
+
+// updateFocusedElement moves the focus by the provided number of elements in
+// navigation order, skipping elements which may not receive focus. When step
+// is 0, the focus is moved forward only if the focused element may not
+// receive focus. If no element may receive focus, the focused element is left
+// unchanged and false is returned. The caller must hold the lock.
+func (f *Form) updateFocusedElement(step int) bool {
+	order := f.elementOrder()
+	l := len(order)
+	if l == 0 {
+		return false
+	}
+
+	position := -1
+	for p, index := range order {
+		if index == f.focusedElement {
+			position = p
+			break
+		}
+	}
+	if position < 0 {
+		position = 0
+	} else {
+		position += step
+	}
+
 	li := len(f.items)
-	l := len(f.items) + len(f.buttons)
 	for i := 0; i < l; i++ {
-		if f.focusedElement < 0 {
+		if position < 0 {
 			if f.wrapAround {
-				f.focusedElement = l - 1
+				position = l - 1
 			} else {
-				f.focusedElement = 0
+				position = 0
 			}
-		} else if f.focusedElement >= l {
+		} else if position >= l {
 			if f.wrapAround {
-				f.focusedElement = 0
+				position = 0
 			} else {
-				f.focusedElement = l - 1
+				position = l - 1
 			}
 		}
 
-		if f.focusedElement < li {
-			item := f.items[f.focusedElement]
+		index := order[position]
+		if index < li {
+			item := f.items[index]
 			if item.GetVisible() {
-				break
+				f.focusedElement = index
+				return true
 			}
 		} else {
-			button := f.buttons[f.focusedElement-li]
-			if button.GetVisible() {
-				break
+			button := f.buttons[index-li]
+			if button.GetVisible() && !button.IsDisabled() {
+				f.focusedElement = index
+				return true
 			}
 		}
 
-		if decreasing {
-			f.focusedElement--
+		if step < 0 {
+			position--
 		} else {
-			f.focusedElement++
+			position++
 		}
 	}
-
+	return false
 }
 
 func (f *Form) formItemInputHandler(delegate func(p Primitive)) func(key tcell.Key) {
@@ -892,14 +1061,12 @@ func (f *Form) formItemInputHandler(delegate func(p Primitive)) func(key tcell.K
 
 		switch key {
 		case tcell.KeyTab, tcell.KeyEnter:
-			f.focusedElement++
-			f.updateFocusedElement(false)
+			f.updateFocusedElement(1)
 			f.Unlock()
 			f.Focus(delegate)
 			f.Lock()
 		case tcell.KeyBacktab:
-			f.focusedElement--
-			f.updateFocusedElement(true)
+			f.updateFocusedElement(-1)
 			f.Unlock()
 			f.Focus(delegate)
 			f.Lock()
@@ -909,8 +1076,10 @@ func (f *Form) formItemInputHandler(delegate func(p Primitive)) func(key tcell.K
 				f.cancel()
 				f.Lock()
 			} else {
-				f.focusedElement = 0
-				f.updateFocusedElement(true)
+				if order := f.elementOrder(); len(order) > 0 {
+					f.focusedElement = order[0]
+				}
+				f.updateFocusedElement(0)
 				f.Unlock()
 				f.Focus(delegate)
 				f.Lock()
@@ -924,18 +1093,16 @@ func (f *Form) formItemInputHandler(delegate func(p Primitive)) func(key tcell.K
 // Focus is called by the application when the primitive receives focus.
 func (f *Form) Focus(delegate func(p Primitive)) {
 	f.Lock()
-	if len(f.items)+len(f.buttons) == 0 {
+
+	// Hand on the focus to one of our child elements. Keep it if none of them
+	// may receive it.
+	if !f.updateFocusedElement(0) {
 		f.hasFocus = true
 		f.Unlock()
 		return
 	}
 	f.hasFocus = false
 
-	// Hand on the focus to one of our child elements.
-	if f.focusedElement < 0 || f.focusedElement >= len(f.items)+len(f.buttons) {
-		f.focusedElement = 0
-	}
-
 	if f.focusedElement < len(f.items) {
 		// We're selecting an item.
 		item := f.items[f.focusedElement]
@@ -1031,6 +1198,9 @@ func setFormItemAttributes(item FormItem, attrs *FormItemAttributes) {
 	item.SetFieldTextColorFocused(attrs.FieldTextColorFocused)
 	item.SetFieldBackgroundColor(attrs.FieldBackgroundColor)
 	item.SetFieldBackgroundColorFocused(attrs.FieldBackgroundColorFocused)
+	if i, ok := item.(interface{ SetValidationErrorColor(tcell.Color) }); ok {
+		i.SetValidationErrorColor(attrs.ValidationErrorColor)
+	}
 
 	if attrs.FinishedFunc != nil {
 		item.SetFinishedFunc(attrs.FinishedFunc)
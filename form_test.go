@@ -0,0 +1,252 @@
+package cview
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFormValidation(t *testing.T) {
+	t.Parallel()
+
+	errRequired := errors.New("required")
+
+	name := NewInputField()
+	name.SetLabel("Name")
+	name.SetValidationFunc(func(text string) error {
+		if text == "" {
+			return errRequired
+		}
+		return nil
+	})
+
+	f := NewForm()
+	f.AddFormItem(name)
+	f.AddInputField("Notes", "", 0, nil, nil)
+
+	var submitted int
+	f.AddSubmitButton("Save", func() {
+		submitted++
+	})
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	f.SetRect(0, 0, 40, 12)
+
+	if errs := f.Validate(); len(errs) != 1 || errs[0] != errRequired {
+		t.Errorf("failed to validate Form: expected [required], got %v", errs)
+	}
+
+	// Submitting is blocked while an item is invalid.
+	save := f.GetButton(0)
+	save.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if submitted != 0 {
+		t.Errorf("failed to block Form submission: submitted %d times", submitted)
+	}
+
+	// The error is shown below the item.
+	f.Draw(app.screen)
+	_, y, _, _ := name.GetRect()
+	x := name.fieldX
+	var row []rune
+	for i := 0; i < len("required"); i++ {
+		r, _, style, _ := app.screen.GetContent(x+i, y+1)
+		if fg, _, _ := style.Decompose(); fg != tcell.ColorRed.TrueColor() {
+			t.Errorf("failed to draw validation error: unexpected color %v", fg)
+			break
+		}
+		row = append(row, r)
+	}
+	if string(row) != "required" {
+		t.Errorf("failed to draw validation error: expected required, got %q", string(row))
+	}
+
+	name.SetText("Alice")
+	if err := name.GetValidationError(); err != nil {
+		t.Errorf("failed to validate InputField on change: got %v", err)
+	}
+	save.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if submitted != 1 {
+		t.Errorf("failed to submit Form: submitted %d times", submitted)
+	}
+}
+
+func TestFormHorizontal(t *testing.T) {
+	t.Parallel()
+
+	f := NewForm()
+	f.SetHorizontal(true)
+	f.SetItemPadding(2)
+	f.AddInputField("A", "", 10, nil, nil)
+	f.AddInputField("B", "", 10, nil, nil)
+	f.AddInputField("C", "", 10, nil, nil)
+	f.AddButton("Go", nil)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	f.SetRect(0, 0, 32, 10)
+	f.Draw(app.screen)
+
+	// Items wrap when their label and field do not fit.
+	for index, expected := range [][2]int{{1, 1}, {15, 1}, {1, 3}} {
+		x, y, _, _ := f.GetFormItem(index).GetRect()
+		if x != expected[0] || y != expected[1] {
+			t.Errorf("failed to lay out horizontal Form item %d: expected %d,%d, got %d,%d", index, expected[0], expected[1], x, y)
+		}
+	}
+	if x, y, _, _ := f.GetButton(0).GetRect(); x != 15 || y != 3 {
+		t.Errorf("failed to lay out horizontal Form button: expected 15,3, got %d,%d", x, y)
+	}
+}
+
+func TestFormDisabledButton(t *testing.T) {
+	t.Parallel()
+
+	var selected []string
+	f := NewForm()
+	f.AddInputField("Name", "", 0, nil, nil)
+	f.AddButton("Submit", func() {
+		selected = append(selected, "Submit")
+	})
+	f.AddButton("Cancel", func() {
+		selected = append(selected, "Cancel")
+	})
+
+	submit := f.GetButton(0)
+	submit.SetDisabled(true)
+	if !submit.IsDisabled() {
+		t.Fatalf("failed to disable Button")
+	}
+
+	var focused Primitive
+	delegate := func(p Primitive) {
+		focused = p
+	}
+	f.Focus(delegate)
+
+	// Tab navigation skips the disabled button.
+	f.formItemInputHandler(delegate)(tcell.KeyTab)
+	if focused != f.GetButton(1) {
+		t.Errorf("failed to skip disabled Button: focused %T", focused)
+	}
+
+	// A disabled button may not be selected.
+	submit.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), delegate)
+	if len(selected) != 0 {
+		t.Errorf("failed to block disabled Button: selected %v", selected)
+	}
+
+	submit.SetDisabled(false)
+	submit.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), delegate)
+	if len(selected) != 1 || selected[0] != "Submit" {
+		t.Errorf("failed to select enabled Button: selected %v", selected)
+	}
+}
+
+func TestFormNoFocusableElements(t *testing.T) {
+	t.Parallel()
+
+	disabled := NewForm()
+	disabled.AddButton("OK", nil)
+	disabled.GetButton(0).SetDisabled(true)
+
+	hidden := NewForm()
+	hidden.AddInputField("Name", "", 0, nil, nil)
+	hidden.GetFormItem(0).SetVisible(false)
+
+	for _, f := range []*Form{disabled, hidden} {
+		var focused Primitive
+		f.Focus(func(p Primitive) {
+			focused = p
+		})
+		if focused != nil {
+			t.Errorf("failed to skip unfocusable elements: focused %T", focused)
+		} else if !f.HasFocus() {
+			t.Errorf("failed to keep focus in Form without focusable elements")
+		}
+	}
+}
+
+func TestFormTabOrder(t *testing.T) {
+	t.Parallel()
+
+	f := NewForm()
+	f.SetWrapAround(true)
+	f.AddInputField("First", "", 0, nil, nil)
+	f.AddInputField("Second", "", 0, nil, nil)
+	f.AddButton("Submit", nil)
+	f.SetTabOrder(1, 2, 1, 7)
+
+	var focused Primitive
+	delegate := func(p Primitive) {
+		focused = p
+	}
+	f.Focus(delegate)
+
+	expected := []Primitive{f.GetFormItem(1), f.GetButton(0), f.GetFormItem(0), f.GetFormItem(1)}
+	for i, p := range expected {
+		if focused != p {
+			t.Errorf("failed to navigate in tab order: unexpected element focused at step %d", i)
+		}
+		f.formItemInputHandler(delegate)(tcell.KeyTab)
+	}
+
+	// Shift-Tab reverses the order.
+	f.formItemInputHandler(delegate)(tcell.KeyBacktab)
+	f.formItemInputHandler(delegate)(tcell.KeyBacktab)
+	if focused != f.GetFormItem(0) {
+		t.Errorf("failed to navigate in reverse tab order: focused %v", focused)
+	}
+}
+
+func TestFormChangedFunc(t *testing.T) {
+	t.Parallel()
+
+	f := NewForm()
+	f.AddInputField("Name", "", 0, nil, nil)
+	f.AddCheckBox("Agree", "", false, nil)
+	f.AddDropDownSimple("Color", 0, nil, "Red", "Green")
+
+	var changed []string
+	f.SetChangedFunc(func(item FormItem) {
+		changed = append(changed, item.GetLabel())
+	})
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	setFocus := func(p Primitive) {
+		app.SetFocus(p)
+	}
+
+	input := f.GetFormItem(0).(*InputField)
+	input.SetText("Bob")
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone), setFocus)
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), setFocus)
+
+	f.GetFormItem(1).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+
+	dd := f.GetFormItem(2).(*DropDown)
+	dd.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+	dd.list.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), setFocus)
+	dd.list.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+
+	expected := []string{"Name", "Agree", "Color"}
+	if fmt.Sprint(changed) != fmt.Sprint(expected) {
+		t.Errorf("failed to notify changes: expected %v, got %v", expected, changed)
+	}
+
+	// Removed items no longer notify the form
+	f.RemoveFormItem(0)
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone), setFocus)
+	if len(changed) != len(expected) {
+		t.Errorf("failed to detach removed item: got %v", changed)
+	}
+}
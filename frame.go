@@ -28,6 +28,9 @@ type Frame struct {
 	// Border spacing.
 	top, bottom, header, footer, left, right int
 
+	// Whether or not to draw a line below the header and above the footer.
+	headerDivider, footerDivider bool
+
 	sync.RWMutex
 }
 
@@ -70,6 +73,27 @@ func (f *Frame) AddText(text string, header bool, align int, color tcell.Color)
 	})
 }
 
+// RemoveText removes a text from the frame. Set "header" to true to remove a
+// text from the header, or false to remove a text from the footer. "index" is
+// the position of the text among the header or footer texts, in the order
+// they were added. Nothing happens if the index is out of range.
+func (f *Frame) RemoveText(index int, header bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	var i int
+	for textIndex, text := range f.text {
+		if text.Header != header {
+			continue
+		}
+		if i == index {
+			f.text = append(f.text[:textIndex], f.text[textIndex+1:]...)
+			return
+		}
+		i++
+	}
+}
+
 // Clear removes all text from the frame.
 func (f *Frame) Clear() {
 	f.Lock()
@@ -88,6 +112,43 @@ func (f *Frame) SetBorders(top, bottom, header, footer, left, right int) {
 	f.top, f.bottom, f.header, f.footer, f.left, f.right = top, bottom, header, footer, left, right
 }
 
+// SetHeaderDivider sets whether or not a horizontal line is drawn below the
+// header text, separating it from the contained primitive.
+func (f *Frame) SetHeaderDivider(divider bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.headerDivider = divider
+}
+
+// SetFooterDivider sets whether or not a horizontal line is drawn above the
+// footer text, separating it from the contained primitive.
+func (f *Frame) SetFooterDivider(divider bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.footerDivider = divider
+}
+
+// drawDivider draws a horizontal line across the frame at the given row,
+// joining the left and right borders if they are visible.
+func (f *Frame) drawDivider(screen tcell.Screen, y int) {
+	horizontal := Borders.Horizontal
+	if f.borderHorizontal != 0 {
+		horizontal = f.borderHorizontal
+	}
+	style := SetAttributes(tcell.StyleDefault.Background(f.backgroundColor).Foreground(f.borderColor), f.borderAttributes)
+
+	x, _, width, _ := f.GetInnerRect()
+	for i := 0; i < width; i++ {
+		screen.SetContent(x+i, y, horizontal, nil, style)
+	}
+	if f.border && f.width >= 2 {
+		screen.SetContent(f.x, y, Borders.LeftT, nil, style)
+		screen.SetContent(f.x+f.width-1, y, Borders.RightT, nil, style)
+	}
+}
+
 // Draw draws this primitive onto the screen.
 func (f *Frame) Draw(screen tcell.Screen) {
 	if !f.GetVisible() {
@@ -141,6 +202,16 @@ func (f *Frame) Draw(screen tcell.Screen) {
 		Print(screen, []byte(text.Text), x, y, width, text.Align, text.Color)
 	}
 
+	// Draw dividers.
+	if f.headerDivider && topMax > top && topMax < bottomMin {
+		f.drawDivider(screen, topMax)
+		topMax++
+	}
+	if f.footerDivider && bottomMin < bottom && bottomMin > topMax {
+		f.drawDivider(screen, bottomMin)
+		bottomMin--
+	}
+
 	// Set the size of the contained primitive.
 	if topMax > top {
 		top = topMax + f.header
@@ -178,6 +249,15 @@ func (f *Frame) HasFocus() bool {
 	return false
 }
 
+// interceptKey passes a key event on to the contained primitive.
+func (f *Frame) interceptKey(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	f.RLock()
+	primitive := f.primitive
+	f.RUnlock()
+
+	return interceptKey(event, setFocus, primitive)
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (f *Frame) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
@@ -0,0 +1,81 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFrameDividers(t *testing.T) {
+	t.Parallel()
+
+	b := NewBox()
+	f := NewFrame(b)
+	f.SetBorders(0, 0, 0, 0, 0, 0)
+	f.AddText("Header", true, AlignLeft, tcell.ColorWhite.TrueColor())
+	f.AddText("Footer", false, AlignLeft, tcell.ColorWhite.TrueColor())
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	f.SetRect(0, 0, 20, 10)
+
+	f.Draw(app.screen)
+	if _, y, _, height := b.GetRect(); y != 1 || height != 8 {
+		t.Errorf("failed to place Frame primitive: expected y 1 and height 8, got %d and %d", y, height)
+	}
+
+	f.SetHeaderDivider(true)
+	f.SetFooterDivider(true)
+	f.Draw(app.screen)
+	if _, y, _, height := b.GetRect(); y != 2 || height != 6 {
+		t.Errorf("failed to place Frame primitive below divider: expected y 2 and height 6, got %d and %d", y, height)
+	}
+	for _, y := range []int{1, 8} {
+		for x := 0; x < 20; x++ {
+			if r, _, _, _ := app.screen.GetContent(x, y); r != Borders.Horizontal {
+				t.Fatalf("failed to draw Frame divider at %d,%d: got %c", x, y, r)
+			}
+		}
+	}
+}
+
+func TestFrameRemoveText(t *testing.T) {
+	t.Parallel()
+
+	f := NewFrame(NewBox())
+	f.AddText("H0", true, AlignLeft, tcell.ColorWhite)
+	f.AddText("F0", false, AlignLeft, tcell.ColorWhite)
+	f.AddText("H1", true, AlignLeft, tcell.ColorWhite)
+	f.AddText("F1", false, AlignLeft, tcell.ColorWhite)
+	f.AddText("H2", true, AlignLeft, tcell.ColorWhite)
+
+	texts := func() string {
+		f.RLock()
+		defer f.RUnlock()
+
+		var s string
+		for _, text := range f.text {
+			s += text.Text
+		}
+		return s
+	}
+
+	f.RemoveText(1, true)
+	if s := texts(); s != "H0F0F1H2" {
+		t.Errorf("failed to remove header text: expected H0F0F1H2, got %s", s)
+	}
+	f.RemoveText(0, false)
+	if s := texts(); s != "H0F1H2" {
+		t.Errorf("failed to remove footer text: expected H0F1H2, got %s", s)
+	}
+
+	// Indices which are out of range are ignored.
+	f.RemoveText(2, true)
+	f.RemoveText(1, false)
+	f.RemoveText(-1, true)
+	if s := texts(); s != "H0F1H2" {
+		t.Errorf("failed to ignore out of range index: expected H0F1H2, got %s", s)
+	}
+}
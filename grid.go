@@ -19,6 +19,13 @@ type gridItem struct {
 	x, y, w, h int  // The last position of the item relative to the top-left corner of the grid. Undefined if visible is false.
 }
 
+// gridBreakpoint represents an alternate definition of the rows and columns of
+// a grid which applies when the grid is at least minGridWidth cells wide.
+type gridBreakpoint struct {
+	minGridWidth  int
+	rows, columns []int
+}
+
 // Grid is an implementation of a grid-based layout. It works by defining the
 // size of the rows and columns, then placing primitives into the grid.
 //
@@ -37,6 +44,10 @@ type Grid struct {
 	// SetRows()/SetColumns() for details.
 	rows, columns []int
 
+	// Alternate definitions of the rows and columns, sorted by minimum grid
+	// width. See SetBreakpoint() for details.
+	breakpoints []gridBreakpoint
+
 	// The minimum sizes for rows and columns.
 	minWidth, minHeight int
 
@@ -141,6 +152,52 @@ func (g *Grid) SetSize(numRows, numColumns, rowSize, columnSize int) {
 	}
 }
 
+// SetBreakpoint defines alternate rows and columns of the grid (see SetRows()
+// and SetColumns()) which apply when the grid is at least minGridWidth cells
+// wide. When drawing, the definition with the largest minimum width not
+// exceeding the width of the grid is used. If no definition applies, the rows
+// and columns set with SetRows() and SetColumns() are used. Setting a
+// breakpoint for the same minimum width again replaces it.
+//
+// Items may be placed differently for each breakpoint by adding them with the
+// corresponding minimum grid width (see AddItem()). Example:
+//
+//	grid.SetColumns(-1)                        // One column in narrow grids.
+//	grid.SetBreakpoint(80, nil, []int{-1, -1, -1}) // Three columns in wide grids.
+//	grid.AddItem(a, 0, 0, 1, 1, 0, 0, false)
+//	grid.AddItem(b, 1, 0, 1, 1, 0, 0, false)
+//	grid.AddItem(a, 0, 0, 1, 1, 0, 80, false)
+//	grid.AddItem(b, 0, 1, 1, 2, 0, 80, false)
+func (g *Grid) SetBreakpoint(minGridWidth int, rows, columns []int) {
+	g.Lock()
+	defer g.Unlock()
+
+	for index, breakpoint := range g.breakpoints {
+		if breakpoint.minGridWidth == minGridWidth {
+			g.breakpoints[index].rows, g.breakpoints[index].columns = rows, columns
+			return
+		}
+	}
+
+	breakpoint := gridBreakpoint{minGridWidth: minGridWidth, rows: rows, columns: columns}
+	index := len(g.breakpoints)
+	for index > 0 && g.breakpoints[index-1].minGridWidth > minGridWidth {
+		index--
+	}
+	g.breakpoints = append(g.breakpoints, gridBreakpoint{})
+	copy(g.breakpoints[index+1:], g.breakpoints[index:])
+	g.breakpoints[index] = breakpoint
+}
+
+// ClearBreakpoints removes all alternate row and column definitions set with
+// SetBreakpoint().
+func (g *Grid) ClearBreakpoints() {
+	g.Lock()
+	defer g.Unlock()
+
+	g.breakpoints = nil
+}
+
 // SetMinSize sets an absolute minimum width for rows and an absolute minimum
 // height for columns. Panics if negative values are provided.
 func (g *Grid) SetMinSize(row, column int) {
@@ -154,6 +211,8 @@ func (g *Grid) SetMinSize(row, column int) {
 }
 
 // SetGap sets the size of the gaps between neighboring primitives on the grid.
+// The gaps are subtracted from the available space before it is distributed
+// among rows and columns. No gaps are added along the outer edges of the grid.
 // If borders are drawn (see SetBorders()), these values are ignored and a gap
 // of 1 is assumed. Panics if negative values are provided.
 func (g *Grid) SetGap(row, column int) {
@@ -270,6 +329,43 @@ func (g *Grid) RemoveItem(p Primitive) {
 	}
 }
 
+// ReplaceItem replaces all items for the old primitive with the new primitive,
+// keeping their grid positions, minimum grid sizes and focus flags.
+func (g *Grid) ReplaceItem(old, new Primitive) {
+	g.Lock()
+	defer g.Unlock()
+
+	for _, item := range g.items {
+		if item.Item == old {
+			item.Item = new
+		}
+	}
+}
+
+// GetItem returns the primitive which occupies the provided grid cell. When
+// several items cover the cell, the one added last among the items which were
+// visible the last time the grid was drawn is returned. If the grid has not
+// been drawn yet, the item added last is returned. Nil is returned if no
+// primitive occupies the cell.
+func (g *Grid) GetItem(row, column int) Primitive {
+	g.RLock()
+	defer g.RUnlock()
+
+	var found Primitive
+	for index := len(g.items) - 1; index >= 0; index-- {
+		item := g.items[index]
+		if item.Item == nil || row < item.Row || row >= item.Row+item.Height || column < item.Column || column >= item.Column+item.Width {
+			continue
+		}
+		if item.visible {
+			return item.Item
+		} else if found == nil {
+			found = item.Item
+		}
+	}
+	return found
+}
+
 // Clear removes all items from the grid.
 func (g *Grid) Clear() {
 	g.Lock()
@@ -313,16 +409,25 @@ func (g *Grid) Focus(delegate func(p Primitive)) {
 	}
 
 	g.Lock()
+	hadFocus := g.hasFocus
 	g.hasFocus = true
 	g.Unlock()
+
+	if !hadFocus {
+		g.focusChanged(true)
+	}
 }
 
 // Blur is called when this primitive loses focus.
 func (g *Grid) Blur() {
 	g.Lock()
-	defer g.Unlock()
-
+	hadFocus := g.hasFocus
 	g.hasFocus = false
+	g.Unlock()
+
+	if hadFocus {
+		g.focusChanged(false)
+	}
 }
 
 // HasFocus returns whether or not this primitive has focus.
@@ -338,6 +443,20 @@ func (g *Grid) HasFocus() bool {
 	return g.hasFocus
 }
 
+// interceptKey passes a key event on to the focused item.
+func (g *Grid) interceptKey(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	g.RLock()
+	var items []Primitive
+	for _, item := range g.items {
+		if item.visible {
+			items = append(items, item.Item)
+		}
+	}
+	g.RUnlock()
+
+	return interceptKey(event, setFocus, items...)
+}
+
 // InputHandler returns the handler for this primitive.
 func (g *Grid) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return g.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
@@ -374,6 +493,15 @@ func (g *Grid) Draw(screen tcell.Screen) {
 	x, y, width, height := g.GetInnerRect()
 	screenWidth, screenHeight := screen.Size()
 
+	// Which row and column definitions apply?
+	gridRows, gridColumns := g.rows, g.columns
+	for _, breakpoint := range g.breakpoints {
+		if width < breakpoint.minGridWidth {
+			break
+		}
+		gridRows, gridColumns = breakpoint.rows, breakpoint.columns
+	}
+
 	// Make a list of items which apply.
 	items := make(map[Primitive]*gridItem)
 	for _, item := range g.items {
@@ -389,8 +517,8 @@ func (g *Grid) Draw(screen tcell.Screen) {
 	}
 
 	// How many rows and columns do we have?
-	rows := len(g.rows)
-	columns := len(g.columns)
+	rows := len(gridRows)
+	columns := len(gridColumns)
 	for _, item := range items {
 		rowEnd := item.Row + item.Height
 		if rowEnd > rows {
@@ -416,7 +544,7 @@ func (g *Grid) Draw(screen tcell.Screen) {
 	remainingHeight := height
 	proportionalWidth := 0
 	proportionalHeight := 0
-	for index, row := range g.rows {
+	for index, row := range gridRows {
 		if row > 0 {
 			if row < g.minHeight {
 				row = g.minHeight
@@ -429,7 +557,7 @@ func (g *Grid) Draw(screen tcell.Screen) {
 			proportionalHeight += -row
 		}
 	}
-	for index, column := range g.columns {
+	for index, column := range gridColumns {
 		if column > 0 {
 			if column < g.minWidth {
 				column = g.minWidth
@@ -449,18 +577,18 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		remainingHeight -= (rows - 1) * g.gapRows
 		remainingWidth -= (columns - 1) * g.gapColumns
 	}
-	if rows > len(g.rows) {
-		proportionalHeight += rows - len(g.rows)
+	if rows > len(gridRows) {
+		proportionalHeight += rows - len(gridRows)
 	}
-	if columns > len(g.columns) {
-		proportionalWidth += columns - len(g.columns)
+	if columns > len(gridColumns) {
+		proportionalWidth += columns - len(gridColumns)
 	}
 
 	// Distribute proportional rows/columns.
 	for index := 0; index < rows; index++ {
 		row := 0
-		if index < len(g.rows) {
-			row = g.rows[index]
+		if index < len(gridRows) {
+			row = gridRows[index]
 		}
 		if row > 0 {
 			if row < g.minHeight {
@@ -482,8 +610,8 @@ func (g *Grid) Draw(screen tcell.Screen) {
 	}
 	for index := 0; index < columns; index++ {
 		column := 0
-		if index < len(g.columns) {
-			column = g.columns[index]
+		if index < len(gridColumns) {
+			column = gridColumns[index]
 		}
 		if column > 0 {
 			if column < g.minWidth {
@@ -0,0 +1,116 @@
+package cview
+
+import (
+	"testing"
+)
+
+func TestGridBreakpoints(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewBox(), NewBox()
+
+	g := NewGrid()
+	g.SetColumns(-1)
+	g.SetBreakpoint(60, nil, []int{20, -1})
+	g.AddItem(a, 0, 0, 1, 1, 0, 0, false)
+	g.AddItem(b, 1, 0, 1, 1, 0, 0, false)
+	g.AddItem(a, 0, 0, 1, 1, 0, 60, false)
+	g.AddItem(b, 0, 1, 1, 1, 0, 60, false)
+
+	app, err := newTestApp(g)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	testCases := []struct {
+		width      int
+		aX, aW     int
+		bX, bY, bW int
+	}{
+		{40, 0, 40, 0, 5, 40},
+		{80, 0, 20, 20, 0, 60},
+	}
+	for _, c := range testCases {
+		g.SetRect(0, 0, c.width, 10)
+		g.Draw(app.screen)
+
+		aX, _, aW, _ := a.GetRect()
+		bX, bY, bW, _ := b.GetRect()
+		if aX != c.aX || aW != c.aW || bX != c.bX || bY != c.bY || bW != c.bW {
+			t.Errorf("failed to apply breakpoint at width %d: got a at %d (width %d), b at %d,%d (width %d)", c.width, aX, aW, bX, bY, bW)
+		}
+	}
+}
+
+func TestGridGap(t *testing.T) {
+	t.Parallel()
+
+	boxes := []*Box{NewBox(), NewBox(), NewBox(), NewBox()}
+
+	g := NewGrid()
+	g.SetRows(-1, -1)
+	g.SetColumns(-1, -1)
+	for i, box := range boxes {
+		g.AddItem(box, i/2, i%2, 1, 1, 0, 0, false)
+	}
+
+	app, err := newTestApp(g)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	testCases := []struct {
+		gapRows, gapColumns int
+		rects               [][4]int
+	}{
+		{0, 0, [][4]int{{0, 0, 10, 5}, {10, 0, 10, 5}, {0, 5, 10, 5}, {10, 5, 10, 5}}},
+		{2, 4, [][4]int{{0, 0, 8, 4}, {12, 0, 8, 4}, {0, 6, 8, 4}, {12, 6, 8, 4}}},
+	}
+	for _, c := range testCases {
+		g.SetGap(c.gapRows, c.gapColumns)
+		g.SetRect(0, 0, 20, 10)
+		g.Draw(app.screen)
+
+		for i, box := range boxes {
+			x, y, w, h := box.GetRect()
+			if [4]int{x, y, w, h} != c.rects[i] {
+				t.Errorf("failed to apply gap %d,%d to item %d: expected %v, got %v", c.gapRows, c.gapColumns, i, c.rects[i], [4]int{x, y, w, h})
+			}
+		}
+	}
+}
+
+func TestGridReplaceItem(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := NewBox(), NewBox(), NewBox()
+
+	g := NewGrid()
+	g.SetRows(-1, -1)
+	g.SetColumns(-1, -1)
+	g.AddItem(a, 0, 0, 1, 2, 0, 0, false)
+	g.AddItem(b, 1, 1, 1, 1, 0, 0, false)
+
+	if p := g.GetItem(0, 1); p != a {
+		t.Errorf("failed to get item spanning columns: got %v", p)
+	} else if p := g.GetItem(1, 0); p != nil {
+		t.Errorf("failed to get empty cell: got %v", p)
+	}
+
+	g.ReplaceItem(a, c)
+	if p := g.GetItem(0, 0); p != c {
+		t.Errorf("failed to replace item: got %v", p)
+	}
+
+	app, err := newTestApp(g)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	g.SetRect(0, 0, 20, 10)
+	g.Draw(app.screen)
+
+	x, y, width, height := c.GetRect()
+	if x != 0 || y != 0 || width != 20 || height != 5 {
+		t.Errorf("failed to keep position of replaced item: got %d,%d %dx%d", x, y, width, height)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"math"
 	"regexp"
+	"strings"
 	"sync"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
@@ -86,6 +88,15 @@ type InputField struct {
 	// The note to show below the input field.
 	fieldNote []byte
 
+	// An optional function which validates the text of the input field.
+	validate func(text string) error
+
+	// The error returned the last time the text was validated.
+	validationError error
+
+	// The text color of the validation error.
+	validationErrorColor tcell.Color
+
 	// The screen width of the label area. A value of 0 means use the width of
 	// the label text.
 	labelWidth int
@@ -98,9 +109,28 @@ type InputField struct {
 	// disables masking.
 	maskCharacter rune
 
+	// An optional input pattern. See SetMask for details.
+	mask []rune
+
+	// Previous states of the input field, restored when undoing and redoing
+	// edits.
+	undoStack []inputFieldState
+	redoStack []inputFieldState
+
+	// The maximum number of states kept in the undo stack.
+	undoLimit int
+
+	// Whether the last edit was a character insertion. Consecutive character
+	// insertions are undone in a single step.
+	undoCoalesce bool
+
 	// The cursor position as a byte index into the text string.
 	cursorPos int
 
+	// The selected text as a range of byte indices into the text string. The
+	// selection is empty when both indices are equal.
+	selectionStart, selectionEnd int
+
 	// An optional autocomplete function which receives the current text of the
 	// input field and returns a slice of ListItems to be displayed in a drop-down
 	// selection. Items' main text is displayed in the autocomplete list. When
@@ -108,6 +138,19 @@ type InputField struct {
 	// the main text is used.
 	autocomplete func(text string) []*ListItem
 
+	// An optional asynchronous autocomplete function which receives the current
+	// text of the input field and returns a channel on which suggestions are
+	// sent as they become available.
+	autocompleteAsync func(text string) <-chan []string
+
+	// Incremented each time asynchronous autocomplete results are requested.
+	// Results belonging to an older request are discarded.
+	autocompleteRequest uint64
+
+	// An optional function which is called when asynchronous autocomplete
+	// results have been applied.
+	autocompleteUpdated func()
+
 	// The List object which shows the selectable autocomplete entries. If not
 	// nil, the list's main texts represent the current autocomplete entries.
 	autocompleteList *List
@@ -121,6 +164,10 @@ type InputField struct {
 	// An optional function which is called when the input has changed.
 	changed func(text string)
 
+	// An optional function which notifies the form this item belongs to when
+	// the value was changed by the user.
+	formChanged func()
+
 	// An optional function which is called when the user indicated that they
 	// are done entering text. The key which was pressed is provided (tab,
 	// shift-tab, enter, or escape).
@@ -155,26 +202,55 @@ func NewInputField() *InputField {
 		autocompleteListSelectedBackgroundColor: Styles.PrimaryTextColor,
 		autocompleteSuggestionTextColor:         Styles.ContrastSecondaryTextColor,
 		fieldNoteTextColor:                      Styles.SecondaryTextColor,
+		validationErrorColor:                    tcell.ColorRed.TrueColor(),
 		labelColorFocused:                       ColorUnset,
 		placeholderTextColorFocused:             ColorUnset,
+		undoLimit:                               100,
 	}
 }
 
-// SetText sets the current text of the input field.
+// SetText sets the current text of the input field. The cursor is moved to
+// the end of the text.
 func (i *InputField) SetText(text string) {
+	i.setText(text, false)
+}
+
+// SetTextKeepCursor sets the current text of the input field without moving
+// the cursor, unless it lies beyond the end of the new text.
+func (i *InputField) SetTextKeepCursor(text string) {
+	i.setText(text, true)
+}
+
+// setText sets the current text of the input field.
+func (i *InputField) setText(text string, keepCursor bool) {
 	i.Lock()
 
 	i.text = []byte(text)
-	i.cursorPos = len(text)
-	if i.changed != nil {
-		i.Unlock()
-		i.changed(text)
+	if i.mask != nil {
+		i.text = []byte(i.applyMask(i.fitMask([]rune(text))))
+	}
+	if keepCursor {
+		i.cursorPos = i.clampPosition(i.cursorPos)
 	} else {
-		i.Unlock()
+		i.cursorPos = len(i.text)
+	}
+	i.selectionStart, i.selectionEnd = 0, 0
+	i.clearHistory()
+	text = string(i.text)
+	validate := i.validate
+	changed := i.changed
+	i.Unlock()
+
+	if validate != nil {
+		i.Validate()
+	}
+	if changed != nil {
+		changed(text)
 	}
 }
 
-// GetText returns the current text of the input field.
+// GetText returns the current text of the input field. When an input mask is
+// set, the text includes the fixed characters of the mask.
 func (i *InputField) GetText() string {
 	i.RLock()
 	defer i.RUnlock()
@@ -182,6 +258,19 @@ func (i *InputField) GetText() string {
 	return string(i.text)
 }
 
+// GetRawText returns the current text of the input field without the fixed
+// characters of the input mask. When no input mask is set, this is the same
+// as GetText.
+func (i *InputField) GetRawText() string {
+	i.RLock()
+	defer i.RUnlock()
+
+	if i.mask == nil {
+		return string(i.text)
+	}
+	return string(i.unmask())
+}
+
 // SetLabel sets the text to be displayed before the input area.
 func (i *InputField) SetLabel(label string) {
 	i.Lock()
@@ -215,6 +304,14 @@ func (i *InputField) SetPlaceholder(text string) {
 	i.placeholder = []byte(text)
 }
 
+// GetPlaceholder returns the text to be displayed when the input text is empty.
+func (i *InputField) GetPlaceholder() string {
+	i.RLock()
+	defer i.RUnlock()
+
+	return string(i.placeholder)
+}
+
 // SetLabelColor sets the color of the label.
 func (i *InputField) SetLabelColor(color tcell.Color) {
 	i.Lock()
@@ -350,6 +447,70 @@ func (i *InputField) ResetFieldNote() {
 	i.fieldNote = nil
 }
 
+// SetValidationFunc sets a handler which validates the text of the input field
+// whenever it changes and when the input field loses focus. When the handler
+// returns an error, its message is shown below the input field until the text
+// is valid again. Provide nil to disable validation.
+func (i *InputField) SetValidationFunc(handler func(text string) error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.validate = handler
+	if handler == nil {
+		i.validationError = nil
+	}
+}
+
+// SetValidationErrorColor sets the text color of validation errors.
+func (i *InputField) SetValidationErrorColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.validationErrorColor = color
+}
+
+// Validate validates the text of the input field using the handler set with
+// SetValidationFunc and returns the resulting error, if any.
+func (i *InputField) Validate() error {
+	i.RLock()
+	validate := i.validate
+	text := string(i.text)
+	i.RUnlock()
+
+	var err error
+	if validate != nil {
+		err = validate(text)
+	}
+
+	i.Lock()
+	i.validationError = err
+	i.Unlock()
+
+	return err
+}
+
+// GetValidationError returns the error returned the last time the text of the
+// input field was validated, or nil if the text was valid.
+func (i *InputField) GetValidationError() error {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.validationError
+}
+
+// Blur is called when this primitive loses focus.
+func (i *InputField) Blur() {
+	i.Box.Blur()
+
+	i.RLock()
+	validate := i.validate
+	i.RUnlock()
+
+	if validate != nil {
+		i.Validate()
+	}
+}
+
 // SetFieldWidth sets the screen width of the input area. A value of 0 means
 // extend as much as possible.
 func (i *InputField) SetFieldWidth(width int) {
@@ -371,13 +532,14 @@ func (i *InputField) GetFieldWidth() int {
 func (i *InputField) GetFieldHeight() int {
 	i.RLock()
 	defer i.RUnlock()
-	if len(i.fieldNote) == 0 {
+	if len(i.fieldNote) == 0 && i.validationError == nil {
 		return 1
 	}
 	return 2
 }
 
-// GetCursorPosition returns the cursor position.
+// GetCursorPosition returns the cursor position as a byte index into the
+// text.
 func (i *InputField) GetCursorPosition() int {
 	i.RLock()
 	defer i.RUnlock()
@@ -385,12 +547,55 @@ func (i *InputField) GetCursorPosition() int {
 	return i.cursorPos
 }
 
-// SetCursorPosition sets the cursor position.
+// SetCursorPosition sets the cursor position as a byte index into the text.
+// The position is clamped to the text and moved back to the start of the rune
+// it points into. Any selection is cleared.
 func (i *InputField) SetCursorPosition(cursorPos int) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.cursorPos = cursorPos
+	i.cursorPos = i.clampPosition(cursorPos)
+	i.selectionStart, i.selectionEnd = 0, 0
+}
+
+// Select selects the text between the provided byte indices, which are
+// clamped to rune boundaries like cursor positions. The cursor is moved to
+// the end of the selection. Typing replaces the selected text while
+// backspace and delete remove it. Any other key clears the selection.
+// Selecting an empty range clears the selection.
+func (i *InputField) Select(start, end int) {
+	i.Lock()
+	defer i.Unlock()
+
+	start, end = i.clampPosition(start), i.clampPosition(end)
+	if start > end {
+		start, end = end, start
+	}
+	i.selectionStart, i.selectionEnd = start, end
+	i.cursorPos = end
+}
+
+// GetSelection returns the selected text and its range as byte indices into
+// the text. The text is empty when nothing is selected.
+func (i *InputField) GetSelection() (text string, start, end int) {
+	i.RLock()
+	defer i.RUnlock()
+
+	return string(i.text[i.selectionStart:i.selectionEnd]), i.selectionStart, i.selectionEnd
+}
+
+// clampPosition returns the provided byte index clamped to the text and moved
+// back to the start of a rune. The caller must hold the lock.
+func (i *InputField) clampPosition(pos int) int {
+	if pos < 0 {
+		return 0
+	} else if pos >= len(i.text) {
+		return len(i.text)
+	}
+	for pos > 0 && !utf8.RuneStart(i.text[pos]) {
+		pos--
+	}
+	return pos
 }
 
 // SetMaskCharacter sets a character that masks user input on a screen. A value
@@ -402,6 +607,317 @@ func (i *InputField) SetMaskCharacter(mask rune) {
 	i.maskCharacter = mask
 }
 
+// SetUndoLimit sets the maximum number of edits which may be undone. A value
+// of 0 disables undo and redo. The default limit is 100 edits.
+//
+// Edits are undone and redone using the shortcuts Keys.Undo and Keys.Redo.
+// Consecutive character insertions are undone in a single step. The history
+// is cleared when the text is set with SetText.
+func (i *InputField) SetUndoLimit(limit int) {
+	i.Lock()
+	defer i.Unlock()
+
+	if limit < 0 {
+		limit = 0
+	}
+	i.undoLimit = limit
+	if len(i.undoStack) > limit {
+		i.undoStack = i.undoStack[len(i.undoStack)-limit:]
+	}
+	if len(i.redoStack) > limit {
+		i.redoStack = i.redoStack[len(i.redoStack)-limit:]
+	}
+}
+
+// inputFieldState is a snapshot of the text and cursor position of an input
+// field.
+type inputFieldState struct {
+	text      []byte
+	cursorPos int
+}
+
+// state returns a snapshot of the text and cursor position. The caller must
+// hold the lock.
+func (i *InputField) state() inputFieldState {
+	return inputFieldState{
+		text:      append([]byte(nil), i.text...),
+		cursorPos: i.cursorPos,
+	}
+}
+
+// pushHistory appends a state to the provided stack, discarding the oldest
+// states when the undo limit is exceeded.
+func (i *InputField) pushHistory(stack []inputFieldState, state inputFieldState) []inputFieldState {
+	if i.undoLimit == 0 {
+		return nil
+	}
+	stack = append(stack, state)
+	if len(stack) > i.undoLimit {
+		stack = stack[len(stack)-i.undoLimit:]
+	}
+	return stack
+}
+
+// recordHistory records an edit in the undo stack. The provided state is the
+// state before the edit. The caller must hold the lock.
+func (i *InputField) recordHistory(previous inputFieldState, insert bool) {
+	if bytes.Equal(previous.text, i.text) {
+		if !insert {
+			i.undoCoalesce = false
+		}
+		return
+	}
+
+	if !insert || !i.undoCoalesce {
+		i.undoStack = i.pushHistory(i.undoStack, previous)
+	}
+	i.undoCoalesce = insert
+	i.redoStack = nil
+}
+
+// restoreHistory restores the last state of the from stack and pushes the
+// current state onto the to stack. The caller must hold the lock.
+func (i *InputField) restoreHistory(from, to *[]inputFieldState) {
+	if len(*from) == 0 {
+		return
+	}
+	state := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = i.pushHistory(*to, i.state())
+
+	i.text = state.text
+	i.cursorPos = state.cursorPos
+	i.selectionStart, i.selectionEnd = 0, 0
+	i.undoCoalesce = false
+}
+
+// clearHistory clears the undo and redo stacks. The caller must hold the lock.
+func (i *InputField) clearHistory() {
+	i.undoStack = nil
+	i.redoStack = nil
+	i.undoCoalesce = false
+}
+
+// SetMask sets an input pattern which the text of the input field must
+// follow, e.g. "(999) 999-9999" or "99/99/9999". The following characters
+// are placeholders for characters entered by the user:
+//
+//	9  A digit
+//	a  A letter
+//	*  A letter or a digit
+//
+// All other characters are fixed and inserted automatically as the user
+// types. The cursor skips fixed characters. The current text is reformatted
+// to fit the pattern. An empty pattern removes the input mask.
+//
+// To mask entered text (e.g. for password fields), use SetMaskCharacter.
+func (i *InputField) SetMask(pattern string) {
+	i.Lock()
+	defer i.Unlock()
+
+	if pattern == "" {
+		i.mask = nil
+		return
+	}
+	i.mask = []rune(pattern)
+	i.text = []byte(i.applyMask(i.fitMask([]rune(string(i.text)))))
+	i.cursorPos = len(i.text)
+	i.selectionStart, i.selectionEnd = 0, 0
+	i.clearHistory()
+}
+
+// maskPlaceholder returns whether the input mask character at the provided
+// position is a placeholder for a character entered by the user.
+func (i *InputField) maskPlaceholder(pos int) bool {
+	if pos < 0 || pos >= len(i.mask) {
+		return false
+	}
+	switch i.mask[pos] {
+	case '9', 'a', '*':
+		return true
+	}
+	return false
+}
+
+// maskAccepts returns whether the provided rune may be entered at the provided
+// position of the input mask.
+func (i *InputField) maskAccepts(pos int, r rune) bool {
+	if pos < 0 || pos >= len(i.mask) {
+		return false
+	}
+	switch i.mask[pos] {
+	case '9':
+		return unicode.IsDigit(r)
+	case 'a':
+		return unicode.IsLetter(r)
+	case '*':
+		return unicode.IsDigit(r) || unicode.IsLetter(r)
+	}
+	return false
+}
+
+// maskPositions returns the positions of the placeholders of the input mask.
+func (i *InputField) maskPositions() []int {
+	var positions []int
+	for pos := range i.mask {
+		if i.maskPlaceholder(pos) {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+// fitMask returns the characters of the provided text which fit the
+// placeholders of the input mask, in order. Other characters are skipped.
+func (i *InputField) fitMask(text []rune) []rune {
+	positions := i.maskPositions()
+	var raw []rune
+	for _, r := range text {
+		if len(raw) == len(positions) {
+			break
+		}
+		if i.maskAccepts(positions[len(raw)], r) {
+			raw = append(raw, r)
+		}
+	}
+	return raw
+}
+
+// validMask returns whether the provided characters fit the placeholders of
+// the input mask.
+func (i *InputField) validMask(raw []rune) bool {
+	positions := i.maskPositions()
+	if len(raw) > len(positions) {
+		return false
+	}
+	for index, r := range raw {
+		if !i.maskAccepts(positions[index], r) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyMask returns the provided characters formatted according to the input
+// mask. Fixed characters are only included when followed by a character
+// entered by the user.
+func (i *InputField) applyMask(raw []rune) string {
+	var (
+		text  []rune
+		fixed []rune
+	)
+	for pos, m := range i.mask {
+		if len(raw) == 0 {
+			break
+		}
+		if !i.maskPlaceholder(pos) {
+			fixed = append(fixed, m)
+			continue
+		}
+		text = append(append(text, fixed...), raw[0])
+		fixed = fixed[:0]
+		raw = raw[1:]
+	}
+	return string(text)
+}
+
+// unmask returns the characters of the text entered by the user, without the
+// fixed characters of the input mask.
+func (i *InputField) unmask() []rune {
+	var raw []rune
+	for pos, r := range []rune(string(i.text)) {
+		if i.maskPlaceholder(pos) {
+			raw = append(raw, r)
+		}
+	}
+	return raw
+}
+
+// handleMaskKey processes key events which edit the text of an input field
+// with an input mask. It returns whether the key event was handled. The caller
+// must hold the lock.
+func (i *InputField) handleMaskKey(event *tcell.EventKey) bool {
+	raw := i.unmask()
+	cursor := len([]rune(string(i.text[:i.cursorPos])))
+
+	// The number of characters entered by the user before the cursor.
+	before := 0
+	for pos := 0; pos < cursor; pos++ {
+		if i.maskPlaceholder(pos) {
+			before++
+		}
+	}
+
+	switch event.Key() {
+	case tcell.KeyRune:
+		r := event.Rune()
+		if event.Modifiers()&tcell.ModAlt > 0 && strings.ContainsRune("aebf", r) {
+			return false // Movement.
+		}
+		newRaw := append(append(append([]rune{}, raw[:before]...), r), raw[before:]...)
+		if !i.validMask(newRaw) {
+			return true
+		}
+		newText := i.applyMask(newRaw)
+		if i.accept != nil && !i.accept(newText, r) {
+			return true
+		}
+		i.text = []byte(newText)
+		i.setMaskCursor(i.maskPositions()[before] + 1)
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if before == 0 {
+			return true
+		}
+		raw = append(raw[:before-1], raw[before:]...)
+		i.text = []byte(i.applyMask(raw))
+		i.setMaskCursor(i.maskPositions()[before-1])
+	case tcell.KeyDelete:
+		if before >= len(raw) {
+			return true
+		}
+		raw = append(raw[:before], raw[before+1:]...)
+		i.text = []byte(i.applyMask(raw))
+		i.setMaskCursor(cursor)
+	case tcell.KeyCtrlU: // Delete all.
+		i.text = nil
+		i.cursorPos = 0
+	case tcell.KeyCtrlK: // Delete until the end of the line.
+		i.text = []byte(i.applyMask(raw[:before]))
+		i.setMaskCursor(cursor)
+	case tcell.KeyCtrlW: // Delete everything before the cursor.
+		i.text = []byte(i.applyMask(raw[before:]))
+		i.setMaskCursor(0)
+	case tcell.KeyLeft:
+		for cursor > 0 {
+			cursor--
+			if i.maskPlaceholder(cursor) {
+				break
+			}
+		}
+		i.setMaskCursor(cursor)
+	case tcell.KeyRight:
+		i.setMaskCursor(cursor + 1)
+	default:
+		return false
+	}
+	return true
+}
+
+// setMaskCursor places the cursor at the provided character position, skipping
+// fixed characters of the input mask. The caller must hold the lock.
+func (i *InputField) setMaskCursor(pos int) {
+	text := []rune(string(i.text))
+	for pos < len(text) && !i.maskPlaceholder(pos) {
+		pos++
+	}
+	if pos > len(text) {
+		pos = len(text)
+	} else if pos < 0 {
+		pos = 0
+	}
+	i.cursorPos = len(string(text[:pos]))
+}
+
 // SetAutocompleteFunc sets an autocomplete callback function which may return
 // ListItems to be selected from a drop-down based on the current text of the
 // input field. The drop-down appears only if len(entries) > 0. The callback is
@@ -411,11 +927,41 @@ func (i *InputField) SetMaskCharacter(mask rune) {
 func (i *InputField) SetAutocompleteFunc(callback func(currentText string) (entries []*ListItem)) {
 	i.Lock()
 	i.autocomplete = callback
+	i.autocompleteAsync = nil
 	i.Unlock()
 
 	i.Autocomplete()
 }
 
+// SetAutocompleteFuncAsync sets an autocomplete callback function which
+// returns a channel on which suggestions for the current text of the input
+// field are sent as they become available. The callback is invoked in the same
+// situations as the callback set with SetAutocompleteFunc. Suggestions
+// received are merged into the drop-down, unless the text of the input field
+// has changed since they were requested, in which case they are discarded.
+// The channel should be closed once all suggestions have been sent.
+//
+// Suggestions are received in a separate goroutine. Use
+// SetAutocompleteUpdatedFunc to redraw the screen when they are applied.
+func (i *InputField) SetAutocompleteFuncAsync(callback func(currentText string) <-chan []string) {
+	i.Lock()
+	i.autocompleteAsync = callback
+	i.autocomplete = nil
+	i.Unlock()
+
+	i.Autocomplete()
+}
+
+// SetAutocompleteUpdatedFunc sets a handler which is called when suggestions
+// provided by an asynchronous autocomplete callback have been applied. This
+// can be used to redraw the screen (e.g. by calling Application.Draw()).
+func (i *InputField) SetAutocompleteUpdatedFunc(handler func()) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteUpdated = handler
+}
+
 // Autocomplete invokes the autocomplete callback (if there is one). If the
 // length of the returned autocomplete entries slice is greater than 0, the
 // input field will present the user with a corresponding drop-down list the
@@ -426,6 +972,19 @@ func (i *InputField) SetAutocompleteFunc(callback func(currentText string) (entr
 // (e.g. in response to events).
 func (i *InputField) Autocomplete() {
 	i.Lock()
+	if i.autocompleteAsync != nil {
+		i.autocompleteRequest++
+		request := i.autocompleteRequest
+		callback := i.autocompleteAsync
+		text := string(i.text)
+		i.Unlock()
+
+		results := callback(text)
+		if results != nil {
+			go i.receiveAutocomplete(request, text, results)
+		}
+		return
+	}
 	if i.autocomplete == nil {
 		i.Unlock()
 		return
@@ -434,17 +993,48 @@ func (i *InputField) Autocomplete() {
 
 	// Do we have any autocomplete entries?
 	entries := i.autocomplete(string(i.text))
+
+	i.Lock()
+	i.setAutocompleteEntries(entries)
+	i.Unlock()
+}
+
+// receiveAutocomplete merges suggestions received from an asynchronous
+// autocomplete callback into the autocomplete list. Suggestions belonging to
+// an outdated request or to text which has since changed are discarded.
+func (i *InputField) receiveAutocomplete(request uint64, text string, results <-chan []string) {
+	var entries []*ListItem
+	for suggestions := range results {
+		i.Lock()
+		if request != i.autocompleteRequest || string(i.text) != text {
+			// Keep draining the channel so the sender is not blocked.
+			i.Unlock()
+			continue
+		}
+
+		for _, suggestion := range suggestions {
+			entries = append(entries, NewListItem(suggestion))
+		}
+		i.setAutocompleteEntries(entries)
+		updated := i.autocompleteUpdated
+		i.Unlock()
+
+		if updated != nil {
+			updated()
+		}
+	}
+}
+
+// setAutocompleteEntries replaces the entries of the autocomplete list. The
+// list is removed when there are no entries. The caller must hold the lock.
+func (i *InputField) setAutocompleteEntries(entries []*ListItem) {
 	if len(entries) == 0 {
 		// No entries, no list.
-		i.Lock()
 		i.autocompleteList = nil
 		i.autocompleteListSuggestion = nil
-		i.Unlock()
 		return
 	}
 
-	i.Lock()
-
 	// Make a list if we have none.
 	if i.autocompleteList == nil {
 		l := NewList()
@@ -473,8 +1063,6 @@ func (i *InputField) Autocomplete() {
 	if currentEntry >= 0 {
 		i.autocompleteList.SetCurrentItem(currentEntry)
 	}
-
-	i.Unlock()
 }
 
 // autocompleteChanged gets called when another item in the
@@ -512,6 +1100,15 @@ func (i *InputField) SetChangedFunc(handler func(text string)) {
 	i.changed = handler
 }
 
+// setFormChangedFunc sets a handler which is called when the value of this
+// input field was changed by the user. It is used by Form.
+func (i *InputField) setFormChangedFunc(handler func()) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.formChanged = handler
+}
+
 // SetDoneFunc sets a handler which is called when the user is done entering
 // text. The callback function is provided with the key that was pressed, which
 // is one of the following:
@@ -657,14 +1254,38 @@ func (i *InputField) Draw(screen tcell.Screen) {
 			drawnText = EscapeBytes(text[i.offset:])
 			Print(screen, drawnText, x, y, fieldWidth, AlignLeft, fieldTextColor)
 		}
+		// Highlight selection. Character indices are compared as the text may
+		// be masked.
+		if i.selectionStart != i.selectionEnd {
+			selectionStart := utf8.RuneCount(i.text[:i.selectionStart])
+			selectionEnd := utf8.RuneCount(i.text[:i.selectionEnd])
+			selectionStyle := tcell.StyleDefault.Foreground(fieldBackgroundColor).Background(fieldTextColor)
+			iterateString(string(text[i.offset:]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+				if screenPos >= fieldWidth {
+					return true
+				}
+				index := utf8.RuneCount(text[:i.offset+textPos])
+				if index >= selectionEnd {
+					return true
+				} else if index >= selectionStart {
+					for cell := screenPos; cell < screenPos+screenWidth && cell < fieldWidth; cell++ {
+						r, c, _, _ := screen.GetContent(x+cell, y)
+						screen.SetContent(x+cell, y, r, c, selectionStyle)
+					}
+				}
+				return false
+			})
+		}
 		// Draw suggestion
 		if i.maskCharacter == 0 && len(i.autocompleteListSuggestion) > 0 {
 			Print(screen, i.autocompleteListSuggestion, x+runewidth.StringWidth(string(drawnText)), y, fieldWidth-runewidth.StringWidth(string(drawnText)), AlignLeft, i.autocompleteSuggestionTextColor)
 		}
 	}
 
-	// Draw field note
-	if len(i.fieldNote) > 0 {
+	// Draw validation error or field note
+	if i.validationError != nil {
+		Print(screen, []byte(Escape(i.validationError.Error())), x, y+1, fieldWidth, AlignLeft, i.validationErrorColor)
+	} else if len(i.fieldNote) > 0 {
 		Print(screen, i.fieldNote, x, y+1, fieldWidth, AlignLeft, i.fieldNoteTextColor)
 	}
 
@@ -707,6 +1328,69 @@ func (i *InputField) Draw(screen tcell.Screen) {
 	}
 }
 
+// PasteHandler returns the handler which inserts pasted text at the cursor.
+// Line breaks and tabs are replaced with spaces. The changed handler is called
+// once for the whole text.
+func (i *InputField) PasteHandler() func(text string, setFocus func(p Primitive)) {
+	return func(text string, setFocus func(p Primitive)) {
+		i.Lock()
+
+		currentText := i.text
+		previous := i.state()
+
+		if start, end := i.selectionStart, i.selectionEnd; start != end {
+			i.selectionStart, i.selectionEnd = 0, 0
+			if i.mask == nil {
+				i.text = append(i.text[:start:start], i.text[end:]...)
+				i.cursorPos = start
+			}
+		}
+
+		text = strings.TrimRight(text, "\r\n")
+		for _, r := range text {
+			if r == '\n' || r == '\t' {
+				r = ' '
+			} else if unicode.IsControl(r) {
+				continue
+			}
+
+			if i.mask != nil {
+				i.handleMaskKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+				continue
+			}
+
+			newText := make([]byte, 0, len(i.text)+utf8.RuneLen(r))
+			newText = append(append(append(newText, i.text[:i.cursorPos]...), string(r)...), i.text[i.cursorPos:]...)
+			if i.accept != nil && !i.accept(string(newText), r) {
+				continue
+			}
+			i.text = newText
+			i.cursorPos += utf8.RuneLen(r)
+		}
+		i.recordHistory(previous, false)
+
+		changed := !bytes.Equal(i.text, currentText)
+		newText := string(i.text)
+		validate := i.validate
+		changedFunc := i.changed
+		formChanged := i.formChanged
+		i.Unlock()
+
+		if changed {
+			i.Autocomplete()
+			if validate != nil {
+				i.Validate()
+			}
+			if changedFunc != nil {
+				changedFunc(newText)
+			}
+			if formChanged != nil {
+				formChanged()
+			}
+		}
+	}
+}
+
 // InputHandler returns the handler for this primitive.
 func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return i.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
@@ -714,16 +1398,29 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 
 		// Trigger changed events.
 		currentText := i.text
+		previous := i.state()
+		var restored bool
 		defer func() {
 			i.Lock()
 			newText := i.text
+			if !restored {
+				insert := event.Key() == tcell.KeyRune && event.Modifiers()&tcell.ModAlt == 0
+				i.recordHistory(previous, insert)
+			}
+			formChanged := i.formChanged
 			i.Unlock()
 
 			if !bytes.Equal(newText, currentText) {
 				i.Autocomplete()
+				if i.validate != nil {
+					i.Validate()
+				}
 				if i.changed != nil {
 					i.changed(string(i.text))
 				}
+				if formChanged != nil {
+					formChanged()
+				}
 			}
 		}()
 
@@ -752,7 +1449,8 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 		// Add character function. Returns whether or not the rune character is
 		// accepted.
 		add := func(r rune) bool {
-			newText := append(append(i.text[:i.cursorPos], []byte(string(r))...), i.text[i.cursorPos:]...)
+			newText := make([]byte, 0, len(i.text)+utf8.RuneLen(r))
+			newText = append(append(append(newText, i.text[:i.cursorPos]...), string(r)...), i.text[i.cursorPos:]...)
 			if i.accept != nil && !i.accept(string(newText), r) {
 				return false
 			}
@@ -771,6 +1469,48 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 			}
 		}
 
+		// Undo and redo.
+		if HitShortcut(event, Keys.Undo) {
+			i.restoreHistory(&i.undoStack, &i.redoStack)
+			restored = true
+			i.Unlock()
+			return
+		} else if HitShortcut(event, Keys.Redo) {
+			i.restoreHistory(&i.redoStack, &i.undoStack)
+			restored = true
+			i.Unlock()
+			return
+		}
+
+		// Replace or remove the selected text.
+		if start, end := i.selectionStart, i.selectionEnd; start != end {
+			i.selectionStart, i.selectionEnd = 0, 0
+			key := event.Key()
+			typed := key == tcell.KeyRune && event.Modifiers()&tcell.ModAlt == 0
+			if i.mask == nil && (typed || key == tcell.KeyBackspace || key == tcell.KeyBackspace2 || key == tcell.KeyDelete) {
+				i.text = append(i.text[:start:start], i.text[end:]...)
+				i.cursorPos = start
+				i.undoCoalesce = false
+				if !typed {
+					i.Unlock()
+					return
+				}
+			}
+		}
+
+		// Process key events of masked input.
+		if i.mask != nil {
+			if i.handleMaskKey(event) {
+				i.Unlock()
+				return
+			}
+			defer func() {
+				i.Lock()
+				i.setMaskCursor(len([]rune(string(i.text[:i.cursorPos]))))
+				i.Unlock()
+			}()
+		}
+
 		// Process key event.
 		switch key := event.Key(); key {
 		case tcell.KeyRune: // Regular character.
@@ -857,6 +1597,7 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 			return
 		case tcell.KeyEscape:
 			if i.autocompleteList != nil {
+				i.autocompleteRequest++
 				i.autocompleteList = nil
 				i.autocompleteListSuggestion = nil
 				i.Unlock()
@@ -911,6 +1652,7 @@ func (i *InputField) MouseHandler() func(action MouseAction, event *tcell.EventM
 		if action == MouseLeftClick && y == rectY {
 			// Determine where to place the cursor.
 			if x >= i.fieldX {
+				i.selectionStart, i.selectionEnd = 0, 0
 				if !iterateString(string(i.text), func(main rune, comb []rune, textPos int, textWidth int, screenPos int, screenWidth int) bool {
 					if x-i.fieldX < screenPos+screenWidth {
 						i.cursorPos = textPos
@@ -920,6 +1662,9 @@ func (i *InputField) MouseHandler() func(action MouseAction, event *tcell.EventM
 				}) {
 					i.cursorPos = len(i.text)
 				}
+				if i.mask != nil {
+					i.setMaskCursor(len([]rune(string(i.text[:i.cursorPos]))))
+				}
 			}
 			setFocus(i)
 			consumed = true
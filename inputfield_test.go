@@ -0,0 +1,344 @@
+package cview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestInputFieldAutocompleteAsync(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+
+	requests := make(map[string]chan []string)
+	i.SetAutocompleteFuncAsync(func(currentText string) <-chan []string {
+		c := make(chan []string)
+		requests[currentText] = c
+		return c
+	})
+
+	updated := make(chan struct{}, 1)
+	i.SetAutocompleteUpdatedFunc(func() {
+		updated <- struct{}{}
+	})
+
+	i.SetText("ap")
+	i.Autocomplete()
+	stale := requests["ap"]
+
+	i.SetText("app")
+	i.Autocomplete()
+	current := requests["app"]
+
+	// Results for outdated text are discarded
+	stale <- []string{"apricot"}
+	close(stale)
+
+	i.RLock()
+	list := i.autocompleteList
+	i.RUnlock()
+	if list != nil {
+		t.Fatalf("failed to discard stale autocomplete results")
+	}
+
+	// Results are merged as they arrive
+	current <- []string{"apple"}
+	<-updated
+	current <- []string{"application"}
+	<-updated
+	close(current)
+
+	i.RLock()
+	list = i.autocompleteList
+	i.RUnlock()
+	if list == nil {
+		t.Fatalf("failed to apply autocomplete results: list is nil")
+	} else if list.GetItemCount() != 2 {
+		t.Fatalf("failed to merge autocomplete results: expected 2 items, got %d", list.GetItemCount())
+	} else if list.GetItem(1).GetMainText() != "application" {
+		t.Errorf("failed to merge autocomplete results: expected application, got %s", list.GetItem(1).GetMainText())
+	}
+}
+
+func TestInputFieldMask(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetMask("(999) 999-9999")
+
+	inputHandler := i.InputHandler()
+	for _, r := range "55x51234567890" {
+		inputHandler(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), nil)
+	}
+	if i.GetText() != "(555) 123-4567" {
+		t.Errorf("failed to apply mask: expected (555) 123-4567, got %s", i.GetText())
+	} else if i.GetRawText() != "5551234567" {
+		t.Errorf("failed to get raw text: expected 5551234567, got %s", i.GetRawText())
+	}
+
+	// Backspace removes the nearest editable character
+	inputHandler(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), nil)
+	inputHandler(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), nil)
+	inputHandler(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), nil)
+	inputHandler(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), nil)
+	inputHandler(tcell.NewEventKey(tcell.KeyBackspace, 0, tcell.ModNone), nil)
+	if i.GetText() != "(555) 124-567" {
+		t.Errorf("failed to remove masked character: expected (555) 124-567, got %s", i.GetText())
+	}
+
+	i.SetMask("")
+	i.SetText("12312020")
+	i.SetMask("99/99/9999")
+	if i.GetText() != "12/31/2020" {
+		t.Errorf("failed to reformat text: expected 12/31/2020, got %s", i.GetText())
+	}
+	i.SetText("1-2-3")
+	if i.GetText() != "12/3" {
+		t.Errorf("failed to set masked text: expected 12/3, got %s", i.GetText())
+	}
+}
+
+func TestInputFieldUndo(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("Hello")
+
+	inputHandler := i.InputHandler()
+	key := func(k tcell.Key, r rune, mod tcell.ModMask) {
+		inputHandler(tcell.NewEventKey(k, r, mod), nil)
+	}
+	for _, r := range " world" {
+		key(tcell.KeyRune, r, tcell.ModNone)
+	}
+	key(tcell.KeyBackspace, 0, tcell.ModNone)
+	key(tcell.KeyBackspace, 0, tcell.ModNone)
+
+	key(tcell.KeyCtrlZ, 0, tcell.ModCtrl)
+	if i.GetText() != "Hello worl" {
+		t.Errorf("failed to undo: expected Hello worl, got %s", i.GetText())
+	}
+	key(tcell.KeyCtrlZ, 0, tcell.ModCtrl)
+	if i.GetText() != "Hello world" {
+		t.Errorf("failed to undo: expected Hello world, got %s", i.GetText())
+	}
+	key(tcell.KeyCtrlZ, 0, tcell.ModCtrl)
+	if i.GetText() != "Hello" {
+		t.Errorf("failed to undo coalesced insertions: expected Hello, got %s", i.GetText())
+	}
+	key(tcell.KeyCtrlZ, 0, tcell.ModCtrl)
+	if i.GetText() != "Hello" {
+		t.Errorf("failed to undo past SetText boundary: expected Hello, got %s", i.GetText())
+	}
+
+	key(tcell.KeyCtrlY, 0, tcell.ModCtrl)
+	if i.GetText() != "Hello world" {
+		t.Errorf("failed to redo: expected Hello world, got %s", i.GetText())
+	}
+
+	// A new edit clears the redo stack
+	key(tcell.KeyRune, '!', tcell.ModNone)
+	key(tcell.KeyCtrlY, 0, tcell.ModCtrl)
+	if i.GetText() != "Hello world!" {
+		t.Errorf("failed to clear redo stack: expected Hello world!, got %s", i.GetText())
+	}
+
+	// Limit
+	i.SetUndoLimit(1)
+	key(tcell.KeyBackspace, 0, tcell.ModNone)
+	key(tcell.KeyBackspace, 0, tcell.ModNone)
+	key(tcell.KeyCtrlZ, 0, tcell.ModCtrl)
+	key(tcell.KeyCtrlZ, 0, tcell.ModCtrl)
+	if i.GetText() != "Hello world" {
+		t.Errorf("failed to limit undo stack: expected Hello world, got %s", i.GetText())
+	}
+}
+
+func TestInputFieldPaste(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("ab")
+	i.SetAcceptanceFunc(func(text string, ch rune) bool {
+		return ch != 'x'
+	})
+
+	var changed []string
+	i.SetChangedFunc(func(text string) {
+		changed = append(changed, text)
+	})
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	var keys []*tcell.EventKey
+	for _, r := range "1x2\t3" {
+		if r == '\t' {
+			keys = append(keys, tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone))
+			continue
+		}
+		keys = append(keys, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	keys = append(keys, tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+
+	var handled int
+	app.paste(i, nil, keys, func(event interface{}) {
+		handled++
+	})
+	if i.GetText() != "ab12 3" {
+		t.Errorf("failed to paste text: expected ab12 3, got %s", i.GetText())
+	} else if len(changed) != 1 {
+		t.Errorf("failed to paste text: expected one change, got %v", changed)
+	} else if handled != 0 {
+		t.Errorf("failed to paste text: %d key events were handled", handled)
+	}
+
+	// Pasting is undone at once.
+	i.InputHandler()(tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModCtrl), nil)
+	if i.GetText() != "ab" {
+		t.Errorf("failed to undo paste: expected ab, got %s", i.GetText())
+	}
+
+	// Primitives which do not handle pastes receive key events.
+	app.paste(NewBox(), nil, keys, func(event interface{}) {
+		handled++
+	})
+	if handled != len(keys) {
+		t.Errorf("failed to handle pasted keys: expected %d key events, got %d", len(keys), handled)
+	}
+
+	// Pasted keys pass through the primitive's input capture.
+	i.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '2' {
+			return nil
+		}
+		return event
+	})
+	app.paste(i, nil, keys, nil)
+	if i.GetText() != "ab1 3" {
+		t.Errorf("failed to capture pasted keys: expected ab1 3, got %s", i.GetText())
+	}
+}
+
+func TestInputFieldPasteCoalesce(t *testing.T) {
+	t.Parallel()
+
+	changed := make(chan string, 10)
+	i := NewInputField()
+	i.SetChangedFunc(func(text string) {
+		changed <- text
+	})
+
+	app, sc, done := runTestApp(i)
+	defer func() {
+		app.Stop()
+		<-done
+	}()
+
+	// Characters received in quick succession are pasted at once.
+	app.QueueUpdateSync(func() {
+		// Hold the event loop until all characters are pending.
+		sc.InjectKey(tcell.KeyF1, 0, tcell.ModNone)
+		for _, r := range "hello" {
+			sc.InjectKey(tcell.KeyRune, r, tcell.ModNone)
+		}
+	})
+	select {
+	case text := <-changed:
+		if text != "hello" {
+			t.Errorf("failed to coalesce characters: expected hello, got %s", text)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("failed to paste coalesced characters")
+	}
+
+	// Characters received further apart are handled one by one.
+	app.QueueUpdateSync(func() {
+		sc.InjectKey(tcell.KeyF1, 0, tcell.ModNone)
+		sc.InjectKey(tcell.KeyRune, 'a', tcell.ModNone)
+		time.Sleep(2 * pasteCoalesceInterval)
+		sc.InjectKey(tcell.KeyRune, 'b', tcell.ModNone)
+	})
+	for _, expected := range []string{"helloa", "helloab"} {
+		select {
+		case text := <-changed:
+			if text != expected {
+				t.Errorf("failed to handle separate characters: expected %s, got %s", expected, text)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("failed to handle separate characters")
+		}
+	}
+}
+
+func TestInputFieldSelection(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("héllo world")
+
+	// Positions are moved back to the start of a rune
+	i.SetCursorPosition(2)
+	if pos := i.GetCursorPosition(); pos != 1 {
+		t.Errorf("failed to clamp cursor position: expected 1, got %d", pos)
+	}
+	i.SetCursorPosition(100)
+	if pos := i.GetCursorPosition(); pos != len("héllo world") {
+		t.Errorf("failed to clamp cursor position: expected %d, got %d", len("héllo world"), pos)
+	}
+
+	i.SetTextKeepCursor("hello")
+	if pos := i.GetCursorPosition(); pos != 5 {
+		t.Errorf("failed to keep cursor position: expected 5, got %d", pos)
+	}
+	i.SetCursorPosition(2)
+	i.SetTextKeepCursor("help")
+	if pos := i.GetCursorPosition(); pos != 2 {
+		t.Errorf("failed to keep cursor position: expected 2, got %d", pos)
+	}
+
+	i.SetText("héllo world")
+	i.Select(6, 0)
+	if text, start, end := i.GetSelection(); text != "héllo" || start != 0 || end != 6 {
+		t.Errorf("failed to select text: expected héllo 0 6, got %s %d %d", text, start, end)
+	}
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	i.SetRect(0, 0, 20, 1)
+	i.Draw(app.screen)
+	for x := 0; x < 7; x++ {
+		_, _, style, _ := app.screen.GetContent(x, 0)
+		_, bg, _ := style.Decompose()
+		if selected := x < 5; selected != (bg == i.fieldTextColor) {
+			t.Errorf("failed to highlight selection: unexpected background at column %d", x)
+		}
+	}
+
+	// Typing replaces the selection
+	inputHandler := i.InputHandler()
+	inputHandler(tcell.NewEventKey(tcell.KeyRune, 'J', tcell.ModNone), nil)
+	if i.GetText() != "J world" {
+		t.Errorf("failed to replace selection: expected J world, got %s", i.GetText())
+	} else if text, _, _ := i.GetSelection(); text != "" {
+		t.Errorf("failed to clear selection: got %s", text)
+	}
+
+	// Delete removes the selection
+	i.Select(1, 7)
+	inputHandler(tcell.NewEventKey(tcell.KeyDelete, 0, tcell.ModNone), nil)
+	if i.GetText() != "J" {
+		t.Errorf("failed to delete selection: expected J, got %s", i.GetText())
+	}
+
+	inputHandler(tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModCtrl), nil)
+	if i.GetText() != "J world" {
+		t.Errorf("failed to undo deletion of selection: expected J world, got %s", i.GetText())
+	}
+}
@@ -32,7 +32,15 @@ type Key struct {
 	MovePreviousPage  []string
 	MoveNextPage      []string
 
+	PreviousTab []string
+	NextTab     []string
+
 	ShowContextMenu []string
+
+	Filter []string
+
+	Undo []string
+	Redo []string
 }
 
 // Keys defines the keyboard shortcuts of an application.
@@ -62,7 +70,15 @@ var Keys = Key{
 	MovePreviousPage:  []string{"PageUp", "Ctrl+B"},
 	MoveNextPage:      []string{"PageDown", "Ctrl+F"},
 
+	PreviousTab: []string{"Ctrl+PageUp"},
+	NextTab:     []string{"Ctrl+PageDown"},
+
 	ShowContextMenu: []string{"Alt+Enter"},
+
+	Filter: []string{"/"},
+
+	Undo: []string{"Ctrl+Z"},
+	Redo: []string{"Ctrl+Y"},
 }
 
 // HitShortcut returns whether the EventKey provided is present in one or more
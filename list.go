@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -12,6 +13,8 @@ import (
 // ListItem represents an item in a List.
 type ListItem struct {
 	disabled      bool        // Whether or not the list item is selectable.
+	header        bool        // Whether or not the list item is a group header.
+	checked       bool        // Whether or not the list item is selected in multi-select mode.
 	mainText      []byte      // The main text of the list item.
 	secondaryText []byte      // A secondary text to be shown underneath the main text.
 	shortcut      rune        // The key to select the list item directly, 0 if there is no shortcut.
@@ -104,6 +107,46 @@ func (l *ListItem) SetSelectedFunc(handler func()) {
 	l.selected = handler
 }
 
+// SetEnabled sets whether the ListItem is selectable. Disabled items are
+// shown dimmed and are skipped when navigating the list.
+func (l *ListItem) SetEnabled(enabled bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.disabled = !enabled
+}
+
+// IsEnabled returns whether the ListItem is selectable.
+func (l *ListItem) IsEnabled() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return !l.disabled
+}
+
+// SetHeader sets whether the ListItem is a header which labels the items
+// following it. Headers span the full width of the list, are drawn in the
+// list's header color and are skipped when navigating the list.
+func (l *ListItem) SetHeader(header bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.header = header
+}
+
+// IsHeader returns whether the ListItem is a header.
+func (l *ListItem) IsHeader() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.header
+}
+
+// selectable returns whether the ListItem may be selected.
+func (l *ListItem) selectable() bool {
+	return !l.disabled && !l.header
+}
+
 // SetReference allows you to store a reference of any type in the item
 func (l *ListItem) SetReference(val interface{}) {
 	l.Lock()
@@ -143,6 +186,9 @@ type List struct {
 	// The item shortcut text color.
 	shortcutColor tcell.Color
 
+	// The text color of header items.
+	headerColor tcell.Color
+
 	// The text color for selected items.
 	selectedTextColor tcell.Color
 
@@ -167,6 +213,9 @@ type List struct {
 	// If true, the selection must remain centered when scrolling.
 	selectedAlwaysCentered bool
 
+	// The minimum number of items kept visible above and below the selection.
+	scrollMargin int
+
 	// If true, the entire row is highlighted when selected.
 	highlightFullLine bool
 
@@ -184,6 +233,9 @@ type List struct {
 	// item.
 	changed func(index int, item *ListItem)
 
+	// An optional function which is called when the list is scrolled.
+	scrollChanged func(items, columns int)
+
 	// An optional function which is called when a list item was selected. This
 	// function will be called even if the list item defines its own callback.
 	selected func(index int, item *ListItem)
@@ -203,6 +255,28 @@ type List struct {
 	// Maximum prefix and suffix width.
 	prefixWidth, suffixWidth int
 
+	// An optional function which is called to draw each item instead of the
+	// default main and secondary text rendering.
+	itemDraw func(screen tcell.Screen, index int, x, y, width int, selected bool)
+
+	// Whether or not multiple items may be selected.
+	multiSelect bool
+
+	// Whether or not the user may filter the list by typing.
+	filterable bool
+
+	// Whether or not the user is currently typing a filter.
+	filtering bool
+
+	// The current filter text.
+	filter string
+
+	// All items of the list while a filter is applied, nil otherwise.
+	unfilteredItems []*ListItem
+
+	// An optional function which determines whether an item matches a filter.
+	filterFunc func(item *ListItem, filter string) bool
+
 	sync.RWMutex
 }
 
@@ -215,6 +289,7 @@ func NewList() *List {
 		mainTextColor:           Styles.PrimaryTextColor,
 		secondaryTextColor:      Styles.TertiaryTextColor,
 		shortcutColor:           Styles.SecondaryTextColor,
+		headerColor:             Styles.SecondaryTextColor,
 		selectedTextColor:       Styles.PrimitiveBackgroundColor,
 		scrollBarColor:          Styles.ScrollBarColor,
 		selectedBackgroundColor: Styles.PrimaryTextColor,
@@ -249,6 +324,7 @@ func (l *List) SetCurrentItem(index int) {
 	previousItem := l.currentItem
 	l.currentItem = index
 
+	itemOffset, columnOffset := l.itemOffset, l.columnOffset
 	l.updateOffset()
 
 	if index != previousItem && index < len(l.items) && l.changed != nil {
@@ -258,6 +334,10 @@ func (l *List) SetCurrentItem(index int) {
 	} else {
 		//l.Unlock()
 	}
+
+	if (l.itemOffset != itemOffset || l.columnOffset != columnOffset) && l.scrollChanged != nil {
+		l.scrollChanged(l.itemOffset, l.columnOffset)
+	}
 }
 
 // GetCurrentItem returns the currently selected list item,
@@ -298,6 +378,9 @@ func (l *List) GetItems() []*ListItem {
 func (l *List) RemoveItem(index int) {
 	l.Lock()
 
+	l.filtering = false
+	l.clearFilter()
+
 	if len(l.items) == 0 {
 		l.Unlock()
 		return
@@ -340,11 +423,24 @@ func (l *List) RemoveItem(index int) {
 }
 
 // SetOffset sets the number of list items and columns by which the list is
-// scrolled down/to the right.
+// scrolled down/to the right. The item offset is clamped so that the last item
+// remains at the bottom of the list when it was last drawn.
+//
+// Calling this function triggers a "scroll changed" event if the offset
+// changes.
 func (l *List) SetOffset(items, columns int) {
+	defer l.scrolled(l.GetOffset())
+
 	l.Lock()
 	defer l.Unlock()
 
+	maxItems := len(l.items) - l.height
+	if l.showSecondaryText {
+		maxItems = len(l.items) - l.height/2
+	}
+	if items > maxItems {
+		items = maxItems
+	}
 	if items < 0 {
 		items = 0
 	}
@@ -364,6 +460,29 @@ func (l *List) GetOffset() (int, int) {
 	return l.itemOffset, l.columnOffset
 }
 
+// SetScrollChangedFunc sets a handler which is called when the number of list
+// items or columns by which the list is scrolled changes, whether or not the
+// selection changes as well. The handler receives the new offsets.
+func (l *List) SetScrollChangedFunc(handler func(items, columns int)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.scrollChanged = handler
+}
+
+// scrolled calls the scroll changed handler if the list offsets differ from
+// the provided offsets.
+func (l *List) scrolled(items, columns int) {
+	l.RLock()
+	handler := l.scrollChanged
+	itemOffset, columnOffset := l.itemOffset, l.columnOffset
+	l.RUnlock()
+
+	if handler != nil && (itemOffset != items || columnOffset != columns) {
+		handler(itemOffset, columnOffset)
+	}
+}
+
 // SetMainTextColor sets the color of the items' main text.
 func (l *List) SetMainTextColor(color tcell.Color) {
 	l.Lock()
@@ -388,6 +507,14 @@ func (l *List) SetShortcutColor(color tcell.Color) {
 	l.shortcutColor = color
 }
 
+// SetHeaderColor sets the text color of header items.
+func (l *List) SetHeaderColor(color tcell.Color) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.headerColor = color
+}
+
 // SetSelectedTextColor sets the text color of selected items.
 func (l *List) SetSelectedTextColor(color tcell.Color) {
 	l.Lock()
@@ -440,6 +567,22 @@ func (l *List) SetSelectedAlwaysCentered(alwaysCentered bool) {
 	l.selectedAlwaysCentered = alwaysCentered
 }
 
+// SetScrollMargin sets the minimum number of items which remain visible above
+// and below the selected item when the list scrolls to it, e.g. when changing
+// the selection with the arrow keys or SetCurrentItem. The margin is reduced
+// when the list is too small to fit it and does not apply at the beginning
+// and end of the list. A value of 0 (the default) scrolls the selected item to
+// the edge of the list.
+func (l *List) SetScrollMargin(margin int) {
+	l.Lock()
+	defer l.Unlock()
+
+	if margin < 0 {
+		margin = 0
+	}
+	l.scrollMargin = margin
+}
+
 // SetHighlightFullLine sets a flag which determines whether the colored
 // background of selected items spans the entire width of the view. If set to
 // true, the highlight spans the entire view. If set to false, only the text of
@@ -559,6 +702,9 @@ func (l *List) AddItem(item *ListItem) {
 func (l *List) InsertItem(index int, item *ListItem) {
 	l.Lock()
 
+	l.filtering = false
+	l.clearFilter()
+
 	// Shift index to range.
 	if index < 0 {
 		index = len(l.items) + index + 1
@@ -591,6 +737,15 @@ func (l *List) InsertItem(index int, item *ListItem) {
 	}
 }
 
+// AddHeader adds a header item with the provided text to the end of the list
+// and returns it. See ListItem.SetHeader for details.
+func (l *List) AddHeader(text string) *ListItem {
+	item := NewListItem(text)
+	item.header = true
+	l.AddItem(item)
+	return item
+}
+
 // GetItem returns the ListItem at the given index.
 // Returns nil when index is out of bounds.
 func (l *List) GetItem(index int) *ListItem {
@@ -637,6 +792,42 @@ func (l *List) SetItemEnabled(index int, enabled bool) {
 	item.disabled = !enabled
 }
 
+// SetMultiSelect sets a flag which determines whether multiple items may be
+// selected. In multi-select mode, pressing Space toggles whether the current
+// item is selected, which is shown by a check mark in front of the item.
+// Pressing Enter still calls the handler set with SetSelectedFunc for the
+// current item.
+func (l *List) SetMultiSelect(multiSelect bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.multiSelect = multiSelect
+}
+
+// SetItemSelected sets whether an item is selected in multi-select mode.
+// Panics if the index is out of range.
+func (l *List) SetItemSelected(index int, selected bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.items[index].checked = selected
+}
+
+// GetSelectedItems returns the indices of the items selected in multi-select
+// mode, in ascending order.
+func (l *List) GetSelectedItems() []int {
+	l.RLock()
+	defer l.RUnlock()
+
+	var indices []int
+	for index, item := range l.items {
+		if item.checked {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
 // SetIndicators is used to set prefix and suffix indicators for selected and unselected items.
 func (l *List) SetIndicators(selectedPrefix, selectedSuffix, unselectedPrefix, unselectedSuffix string) {
 	l.Lock()
@@ -655,6 +846,134 @@ func (l *List) SetIndicators(selectedPrefix, selectedSuffix, unselectedPrefix, u
 	}
 }
 
+// SetItemDrawFunc sets a function which is called to draw each list item in
+// place of the default main and secondary text rendering. The function
+// receives the index of the item, its position and available width, and
+// whether the item is currently selected. Each item occupies the same number
+// of rows as it would by default: one row, or two rows when secondary texts
+// are shown. Navigation and mouse handling are unaffected.
+//
+// Provide nil to restore the default rendering.
+func (l *List) SetItemDrawFunc(handler func(screen tcell.Screen, index int, x, y, width int, selected bool)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.itemDraw = handler
+}
+
+// SetFilterable sets a flag which determines whether the user may filter the
+// list by pressing the Filter key (see Keys) and typing. While typing, only
+// the items matching the filter are shown and the first matching item is
+// selected. Press Enter to keep the filter, or Escape to clear it.
+func (l *List) SetFilterable(filterable bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.filterable = filterable
+}
+
+// SetFilterFunc sets the function which determines whether an item matches the
+// filter. By default, items whose main text contains the filter (ignoring
+// case) match.
+func (l *List) SetFilterFunc(handler func(item *ListItem, filter string) bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.filterFunc = handler
+}
+
+// SetFilter applies a filter to the list. Only the items matching the filter
+// are shown and the first matching item is selected. Provide an empty string
+// to clear the filter and show all items.
+//
+// While a filter is applied, item indices (including those passed to handlers)
+// refer to the matching items only. Adding, inserting, removing or clearing
+// items clears the filter.
+func (l *List) SetFilter(filter string) {
+	l.Lock()
+
+	index, item := l.applyFilter(filter)
+	changed := l.changed
+
+	l.Unlock()
+
+	if item != nil && changed != nil {
+		changed(index, item)
+	}
+}
+
+// GetFilter returns the filter currently applied to the list.
+func (l *List) GetFilter() string {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.filter
+}
+
+// applyFilter applies the provided filter. When the selected item changes, its
+// index and the item are returned.
+func (l *List) applyFilter(filter string) (int, *ListItem) {
+	var previous *ListItem
+	if l.currentItem >= 0 && l.currentItem < len(l.items) {
+		previous = l.items[l.currentItem]
+	}
+
+	if filter == "" {
+		l.clearFilter()
+	} else {
+		if l.unfilteredItems == nil {
+			l.unfilteredItems = l.items
+		}
+		l.filter = filter
+
+		filterFunc := l.filterFunc
+		if filterFunc == nil {
+			lowerFilter := bytes.ToLower([]byte(filter))
+			filterFunc = func(item *ListItem, filter string) bool {
+				return bytes.Contains(bytes.ToLower(item.mainText), lowerFilter)
+			}
+		}
+
+		l.items = nil
+		for _, item := range l.unfilteredItems {
+			if filterFunc(item, filter) {
+				l.items = append(l.items, item)
+			}
+		}
+		l.transform(TransformFirstItem)
+	}
+
+	if l.currentItem < 0 || l.currentItem >= len(l.items) || l.items[l.currentItem] == previous {
+		return 0, nil
+	}
+	return l.currentItem, l.items[l.currentItem]
+}
+
+// clearFilter shows all items again, keeping the current selection.
+func (l *List) clearFilter() {
+	l.filter = ""
+	if l.unfilteredItems == nil {
+		return
+	}
+
+	var current *ListItem
+	if l.currentItem >= 0 && l.currentItem < len(l.items) {
+		current = l.items[l.currentItem]
+	}
+
+	l.items = l.unfilteredItems
+	l.unfilteredItems = nil
+	l.currentItem = 0
+	for index, item := range l.items {
+		if item == current {
+			l.currentItem = index
+			break
+		}
+	}
+	l.itemOffset = 0
+	l.updateOffset()
+}
+
 // FindItems searches the main and secondary texts for the given strings and
 // returns a list of item indices in which those strings are found. One of the
 // two search strings may be empty, it will then be ignored. Indices are always
@@ -707,6 +1026,9 @@ func (l *List) Clear() {
 	defer l.Unlock()
 
 	l.items = nil
+	l.unfilteredItems = nil
+	l.filter = ""
+	l.filtering = false
 	l.currentItem = 0
 	l.itemOffset = 0
 	l.columnOffset = 0
@@ -716,14 +1038,19 @@ func (l *List) Clear() {
 func (l *List) Focus(delegate func(p Primitive)) {
 	l.Box.Focus(delegate)
 	if l.ContextMenu.open {
-		delegate(l.ContextMenu.list)
+		l.ContextMenu.l.RLock()
+		list := l.ContextMenu.focusedList()
+		l.ContextMenu.l.RUnlock()
+		delegate(list)
 	}
 }
 
 // HasFocus returns whether or not this primitive has focus.
 func (l *List) HasFocus() bool {
 	if l.ContextMenu.open {
-		return l.ContextMenu.list.HasFocus()
+		l.ContextMenu.l.RLock()
+		defer l.ContextMenu.l.RUnlock()
+		return l.ContextMenu.focused()
 	}
 
 	l.RLock()
@@ -733,6 +1060,8 @@ func (l *List) HasFocus() bool {
 
 // Transform modifies the current selection.
 func (l *List) Transform(tr Transformation) {
+	defer l.scrolled(l.GetOffset())
+
 	l.Lock()
 
 	previousItem := l.currentItem
@@ -750,6 +1079,7 @@ func (l *List) Transform(tr Transformation) {
 
 func (l *List) transform(tr Transformation) {
 	var decreasing bool
+	previousItem := l.currentItem
 
 	pageItems := l.height
 	if l.showSecondaryText {
@@ -779,6 +1109,7 @@ func (l *List) transform(tr Transformation) {
 		l.itemOffset += pageItems
 	}
 
+	var found bool
 	for i := 0; i < len(l.items); i++ {
 		if l.currentItem < 0 {
 			if l.wrapAround {
@@ -797,7 +1128,8 @@ func (l *List) transform(tr Transformation) {
 		}
 
 		item := l.items[l.currentItem]
-		if !item.disabled && (item.shortcut > 0 || len(item.mainText) > 0 || len(item.secondaryText) > 0) {
+		if item.selectable() && (item.shortcut > 0 || len(item.mainText) > 0 || len(item.secondaryText) > 0) {
+			found = true
 			break
 		}
 
@@ -808,27 +1140,40 @@ func (l *List) transform(tr Transformation) {
 		}
 	}
 
+	// Keep the selection when there is no selectable item to move to.
+	if !found && previousItem >= 0 && previousItem < len(l.items) {
+		l.currentItem = previousItem
+	}
+
 	l.updateOffset()
 }
 
 func (l *List) updateOffset() {
 	_, _, _, l.height = l.GetInnerRect()
+	if l.filtering || l.filter != "" {
+		l.height-- // Reserve space for the filter.
+	}
 
 	h := l.height
 	if l.selectedAlwaysCentered {
 		h /= 2
 	}
 
-	if l.currentItem < l.itemOffset {
-		l.itemOffset = l.currentItem
-	} else if l.showSecondaryText {
-		if 2*(l.currentItem-l.itemOffset) >= h-1 {
-			l.itemOffset = (2*l.currentItem + 3 - h) / 2
-		}
-	} else {
-		if l.currentItem-l.itemOffset >= h {
-			l.itemOffset = l.currentItem + 1 - h
-		}
+	// The number of visible items.
+	n := h
+	if l.showSecondaryText {
+		n /= 2
+	}
+
+	margin := l.scrollMargin
+	if margin > (n-1)/2 {
+		margin = (n - 1) / 2
+	}
+
+	if l.currentItem-margin < l.itemOffset {
+		l.itemOffset = l.currentItem - margin
+	} else if l.currentItem+margin-l.itemOffset >= n {
+		l.itemOffset = l.currentItem + margin + 1 - n
 	}
 
 	if l.showSecondaryText {
@@ -856,6 +1201,9 @@ func (l *List) updateOffset() {
 		if option.shortcut != 0 {
 			strWidth += 4
 		}
+		if l.multiSelect {
+			strWidth += 4
+		}
 
 		if strWidth > maxWidth {
 			maxWidth = strWidth
@@ -897,6 +1245,15 @@ func (l *List) Draw(screen tcell.Screen) {
 	fullWidth := width + l.paddingLeft + l.paddingRight + l.prefixWidth + l.suffixWidth
 	bottomLimit := y + height
 
+	// Draw the filter at the bottom.
+	if l.filtering || l.filter != "" {
+		if height > 0 {
+			height--
+			bottomLimit--
+			Print(screen, []byte(Escape("Filter: "+l.filter)), x, bottomLimit, width, AlignLeft, l.mainTextColor)
+		}
+	}
+
 	l.height = height
 
 	screenWidth, _ := screen.Size()
@@ -939,6 +1296,29 @@ func (l *List) Draw(screen tcell.Screen) {
 			break
 		}
 
+		if l.itemDraw != nil {
+			// Shortcuts.
+			if showShortcuts && item.shortcut != 0 {
+				Print(screen, []byte(fmt.Sprintf("(%c)", item.shortcut)), x-5, y, 4, AlignRight, l.shortcutColor)
+			}
+
+			selected := index == l.currentItem && (!l.selectedFocusOnly || hasFocus)
+			itemDraw := l.itemDraw
+			l.Unlock()
+			itemDraw(screen, index, x, y, width, selected)
+			l.Lock()
+
+			rows := 1
+			if l.showSecondaryText {
+				rows = 2
+			}
+			for i := 0; i < rows && y < bottomLimit; i++ {
+				RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
+				y++
+			}
+			continue
+		}
+
 		mainText := item.mainText
 		secondaryText := item.secondaryText
 		if l.columnOffset > 0 {
@@ -964,6 +1344,26 @@ func (l *List) Draw(screen tcell.Screen) {
 			continue
 		}
 
+		if item.header {
+			PrintStyle(screen, mainText, leftEdge, y, width+x-leftEdge, AlignLeft, SetAttributes(tcell.StyleDefault.Foreground(l.headerColor), tcell.AttrBold))
+
+			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
+			y++
+			if l.showSecondaryText && y < bottomLimit {
+				RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
+				y++
+			}
+			continue
+		}
+
+		if l.multiSelect {
+			checkMark := ' '
+			if item.checked {
+				checkMark = Styles.CheckBoxCheckedRune
+			}
+			mainText = append([]byte(Escape(fmt.Sprintf("[%c] ", checkMark))), mainText...)
+		}
+
 		if index == l.currentItem {
 			if len(l.selectedPrefix) > 0 {
 				mainText = append(l.selectedPrefix, mainText...)
@@ -1053,27 +1453,7 @@ func (l *List) Draw(screen tcell.Screen) {
 
 		x, y, width, height = l.GetInnerRect()
 
-		// What's the longest option text?
-		maxWidth := 0
-		for _, option := range ctx.items {
-			strWidth := TaggedTextWidth(option.mainText)
-			if option.shortcut != 0 {
-				strWidth += 4
-			}
-			if strWidth > maxWidth {
-				maxWidth = strWidth
-			}
-		}
-
-		lheight := len(ctx.items)
-		lwidth := maxWidth
-
-		// Add space for borders
-		lwidth += 2
-		lheight += 2
-
-		lwidth += ctx.paddingLeft + ctx.paddingRight
-		lheight += ctx.paddingTop + ctx.paddingBottom
+		lwidth, lheight := l.ContextMenu.listSize()
 
 		cx, cy := l.ContextMenu.x, l.ContextMenu.y
 		if cx < 0 || cy < 0 {
@@ -1089,19 +1469,15 @@ func (l *List) Draw(screen tcell.Screen) {
 			cx, cy = x+offsetX, y+offsetY
 		}
 
-		_, sheight := screen.Size()
-		if cy+lheight >= sheight && cy-2 > lheight-cy {
-			for i := (cy + lheight) - sheight; i > 0; i-- {
-				cy--
-				if cy+lheight < sheight {
-					break
-				}
-			}
+		// Shift the menu up and to the left when it doesn't fit.
+		swidth, sheight := screen.Size()
+		if cy+lheight > sheight {
+			cy = sheight - lheight
 			if cy < 0 {
 				cy = 0
 			}
 		}
-		if cy+lheight >= sheight {
+		if cy+lheight > sheight {
 			lheight = sheight - cy
 		}
 
@@ -1109,16 +1485,78 @@ func (l *List) Draw(screen tcell.Screen) {
 			lwidth++ // Add space for scroll bar
 		}
 
+		if cx+lwidth > swidth {
+			cx = swidth - lwidth
+			if cx < 0 {
+				cx = 0
+			}
+		}
+
 		ctx.SetRect(cx, cy, lwidth, lheight)
 		ctx.Draw(screen)
+		l.ContextMenu.drawSubMenus(screen)
 	}
 }
 
 // InputHandler returns the handler for this primitive.
 func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return l.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		defer l.scrolled(l.GetOffset())
+
 		l.Lock()
 
+		// Filter items.
+		filter := l.filter
+		var editFilter bool
+		if l.filtering {
+			switch key := event.Key(); {
+			case key == tcell.KeyRune:
+				filter += string(event.Rune())
+				editFilter = true
+			case key == tcell.KeyBackspace || key == tcell.KeyBackspace2:
+				if len(filter) > 0 {
+					_, size := utf8.DecodeLastRuneInString(filter)
+					filter = filter[:len(filter)-size]
+				}
+				editFilter = true
+			case HitShortcut(event, Keys.Select):
+				l.filtering = false
+			}
+		} else if l.filterable && !l.ContextMenu.open && HitShortcut(event, Keys.Filter) {
+			l.filtering = true
+			l.updateOffset()
+			l.Unlock()
+			return
+		}
+		if !l.ContextMenu.open && HitShortcut(event, Keys.Cancel) && (l.filtering || l.filter != "") {
+			l.filtering = false
+			filter = ""
+			editFilter = true
+		}
+		if editFilter {
+			index, item := l.applyFilter(filter)
+			l.updateOffset()
+			changed := l.changed
+			l.Unlock()
+
+			if item != nil && changed != nil {
+				changed(index, item)
+			}
+			return
+		}
+
+		// Toggle the current item in multi-select mode.
+		if l.multiSelect && !l.ContextMenu.open && HitShortcut(event, Keys.Select2) {
+			if l.currentItem >= 0 && l.currentItem < len(l.items) {
+				item := l.items[l.currentItem]
+				if item.selectable() {
+					item.checked = !item.checked
+				}
+			}
+			l.Unlock()
+			return
+		}
+
 		if HitShortcut(event, Keys.Cancel) {
 			if l.ContextMenu.open {
 				l.Unlock()
@@ -1137,7 +1575,7 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 		} else if HitShortcut(event, Keys.Select, Keys.Select2) {
 			if l.currentItem >= 0 && l.currentItem < len(l.items) {
 				item := l.items[l.currentItem]
-				if !item.disabled {
+				if item.selectable() {
 					if item.selected != nil {
 						l.Unlock()
 						item.selected()
@@ -1162,7 +1600,7 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 			if ch != ' ' {
 				// It's not a space bar. Is it a shortcut?
 				for index, item := range l.items {
-					if !item.disabled && item.shortcut == ch {
+					if item.selectable() && item.shortcut == ch {
 						// We have a shortcut.
 						l.currentItem = index
 
@@ -1260,19 +1698,35 @@ func (l *List) indexAtPoint(x, y int) int {
 // MouseHandler returns the mouse handler for this primitive.
 func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return l.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		defer l.scrolled(l.GetOffset())
+
+		// Capture mouse events while the context menu is visible.
+		defer func() {
+			if capture == nil && l.ContextMenuVisible() {
+				capture = l
+			}
+		}()
+
 		l.Lock()
 
 		// Pass events to context menu.
-		if l.ContextMenuVisible() && l.ContextMenuList().InRect(event.Position()) {
-			defer l.ContextMenuList().MouseHandler()(action, event, setFocus)
-			consumed = true
-			l.Unlock()
-			return
+		if l.ContextMenuVisible() {
+			if list := l.ContextMenu.listAtPoint(event.Position()); list != nil {
+				defer list.MouseHandler()(action, event, setFocus)
+				consumed = true
+				l.Unlock()
+				return
+			}
 		}
 
 		if !l.InRect(event.Position()) {
+			// Clicking outside of the context menu hides it.
+			if l.ContextMenuVisible() && (action == MouseLeftDown || action == MouseMiddleDown || action == MouseRightDown) {
+				defer l.ContextMenu.HideContextMenu(setFocus)
+				consumed = true
+			}
 			l.Unlock()
-			return false, nil
+			return consumed, nil
 		}
 
 		// Process mouse event.
@@ -1292,7 +1746,8 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			index := l.indexAtPoint(event.Position())
 			if index != -1 {
 				item := l.items[index]
-				if !item.disabled {
+				if item.selectable() {
+					previousItem := l.currentItem
 					l.currentItem = index
 					if item.selected != nil {
 						l.Unlock()
@@ -1304,7 +1759,7 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 						l.selected(index, item)
 						l.Lock()
 					}
-					if index != l.currentItem && l.changed != nil {
+					if index != previousItem && l.changed != nil {
 						l.Unlock()
 						l.changed(index, item)
 						l.Lock()
@@ -1330,9 +1785,10 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			index := l.indexAtPoint(event.Position())
 			if index != -1 {
 				item := l.items[index]
-				if !item.disabled {
+				if item.selectable() {
+					previousItem := l.currentItem
 					l.currentItem = index
-					if index != l.currentItem && l.changed != nil {
+					if index != previousItem && l.changed != nil {
 						l.Unlock()
 						l.changed(index, item)
 						l.Lock()
@@ -1349,7 +1805,7 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 				index := l.indexAtY(y)
 				if index >= 0 {
 					item := l.items[index]
-					if !item.disabled {
+					if item.selectable() {
 						l.currentItem = index
 					}
 				}
@@ -1370,6 +1826,14 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 				l.itemOffset++
 			}
 			consumed = true
+		case MouseScrollLeft:
+			l.columnOffset--
+			l.updateOffset()
+			consumed = true
+		case MouseScrollRight:
+			l.columnOffset++
+			l.updateOffset()
+			consumed = true
 		}
 
 		l.Unlock()
@@ -1,7 +1,10 @@
 package cview
 
 import (
+	"fmt"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -73,3 +76,324 @@ func TestList(t *testing.T) {
 
 	l.Draw(app.screen)
 }
+
+func TestListItemDrawFunc(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.AddItem(NewListItem(listTextA))
+	l.AddItem(NewListItem(listTextB))
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	var drawn []int
+	l.SetItemDrawFunc(func(screen tcell.Screen, index int, x, y, width int, selected bool) {
+		if selected != (index == 0) {
+			t.Errorf("failed to draw List item %d: unexpected selected state %v", index, selected)
+		}
+		drawn = append(drawn, index)
+		screen.SetContent(x, y, 'X', nil, tcell.StyleDefault)
+	})
+
+	l.Draw(app.screen)
+
+	if len(drawn) != 2 || drawn[0] != 0 || drawn[1] != 1 {
+		t.Errorf("failed to draw List: expected custom draw of items [0 1], got %v", drawn)
+	}
+
+	x, y, _, _ := l.GetInnerRect()
+	for i := 0; i < 2; i++ {
+		if r, _, _, _ := app.screen.GetContent(x, y+i); r != 'X' {
+			t.Errorf("failed to draw List item %d: expected X, got %c", i, r)
+		}
+	}
+}
+
+func TestListFilter(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetFilterable(true)
+	for _, text := range []string{"apple", "Banana", "cherry", "blueberry"} {
+		l.AddItem(NewListItem(text))
+	}
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	l.SetRect(0, 0, 20, 10)
+	l.Draw(app.screen)
+
+	expectListItems := func(current string, expected ...string) {
+		t.Helper()
+
+		var items []string
+		for _, item := range l.GetItems() {
+			items = append(items, item.GetMainText())
+		}
+		if fmt.Sprint(items) != fmt.Sprint(expected) {
+			t.Errorf("failed to filter List: expected items %v, got %v", expected, items)
+		}
+		if item := l.GetCurrentItem(); item == nil || item.GetMainText() != current {
+			t.Errorf("failed to filter List: expected current item %s, got %v", current, item)
+		}
+	}
+
+	handler := l.InputHandler()
+	for _, r := range "/bA" {
+		handler(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), nil)
+	}
+	expectListItems("Banana", "Banana")
+
+	handler(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone), nil)
+	expectListItems("Banana", "Banana", "blueberry")
+
+	// Navigation keys move the selection while filtering.
+	handler(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), nil)
+	expectListItems("blueberry", "Banana", "blueberry")
+
+	// Clearing the filter keeps the selection.
+	handler(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), nil)
+	expectListItems("blueberry", "apple", "Banana", "cherry", "blueberry")
+	if index := l.GetCurrentItemIndex(); index != 3 {
+		t.Errorf("failed to clear List filter: expected current item 3, got %d", index)
+	}
+
+	l.SetFilter("err")
+	expectListItems("cherry", "cherry", "blueberry")
+	if filter := l.GetFilter(); filter != "err" {
+		t.Errorf("failed to get List filter: expected err, got %s", filter)
+	}
+
+	// Adding items clears the filter.
+	l.AddItem(NewListItem("date"))
+	expectListItems("cherry", "apple", "Banana", "cherry", "blueberry", "date")
+}
+
+func TestListMultiSelect(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetMultiSelect(true)
+	l.AddItem(NewListItem(listTextA))
+	l.AddItem(NewListItem(listTextB))
+	l.AddItem(NewListItem(listTextC))
+
+	var selected []int
+	l.SetSelectedFunc(func(index int, item *ListItem) {
+		selected = append(selected, index)
+	})
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	l.SetRect(0, 0, 30, 10)
+
+	l.SetItemSelected(2, true)
+	handler := l.InputHandler()
+	handler(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), nil)
+	if items := l.GetSelectedItems(); fmt.Sprint(items) != "[0 2]" {
+		t.Errorf("failed to toggle List item: expected [0 2], got %v", items)
+	}
+
+	handler(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	if fmt.Sprint(selected) != "[0]" {
+		t.Errorf("failed to select List item: expected [0], got %v", selected)
+	}
+
+	l.Draw(app.screen)
+	for y, expected := range []string{"[X] " + listTextA, "[ ] " + listTextB, "[X] " + listTextC} {
+		var row []rune
+		for x := 0; x < len(expected); x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			row = append(row, r)
+		}
+		if string(row) != expected {
+			t.Errorf("failed to draw List check mark at row %d: expected %q, got %q", y, expected, string(row))
+		}
+	}
+}
+
+func TestListDisabledItems(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	a, b, c := NewListItem(listTextA), NewListItem(listTextB), NewListItem(listTextC)
+	l.AddItem(a)
+	l.AddItem(b)
+	l.AddItem(c)
+
+	var selected int
+	l.SetSelectedFunc(func(index int, item *ListItem) {
+		selected++
+	})
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	l.SetRect(0, 0, 30, 10)
+	l.Draw(app.screen)
+
+	handler := l.InputHandler()
+	down := tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	enter := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+
+	// Navigation skips disabled items.
+	b.SetEnabled(false)
+	handler(down, nil)
+	if index := l.GetCurrentItemIndex(); index != 2 {
+		t.Errorf("failed to skip disabled List item: expected 2, got %d", index)
+	}
+
+	// The cursor does not move when no other item is enabled.
+	l.SetItemEnabled(0, false)
+	handler(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), nil)
+	if index := l.GetCurrentItemIndex(); index != 2 {
+		t.Errorf("failed to keep List selection: expected 2, got %d", index)
+	}
+
+	// Disabled items are not selected.
+	c.SetEnabled(false)
+	handler(enter, nil)
+	handler(down, nil)
+	if index := l.GetCurrentItemIndex(); index != 2 {
+		t.Errorf("failed to keep List selection: expected 2, got %d", index)
+	}
+	if selected != 0 || c.IsEnabled() {
+		t.Errorf("failed to disable List item: selected %d times", selected)
+	}
+}
+
+func TestListScrollChanged(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	for i := 0; i < 20; i++ {
+		l.AddItem(NewListItem(fmt.Sprintf("Item %d", i)))
+	}
+
+	var scrolled []int
+	l.SetScrollChangedFunc(func(items, columns int) {
+		scrolled = append(scrolled, items)
+	})
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	l.SetRect(0, 0, 30, 10)
+	l.Draw(app.screen)
+
+	// The offset is clamped to the last page.
+	l.SetOffset(15, 0)
+	if items, _ := l.GetOffset(); items != 10 {
+		t.Errorf("failed to clamp List offset: expected 10, got %d", items)
+	}
+
+	// Setting the same offset again does not trigger the handler.
+	l.SetOffset(10, 0)
+
+	// Scrolling with the mouse wheel does not change the selection.
+	app.fireMouseActions(tcell.NewEventMouse(1, 1, tcell.WheelUp, tcell.ModNone))
+	if items, _ := l.GetOffset(); items != 9 {
+		t.Errorf("failed to scroll List: expected offset 9, got %d", items)
+	} else if index := l.GetCurrentItemIndex(); index != 0 {
+		t.Errorf("failed to keep List selection: expected 0, got %d", index)
+	}
+
+	// Selecting an item outside of the view scrolls to it.
+	l.SetCurrentItem(0)
+	if len(scrolled) != 3 || scrolled[0] != 10 || scrolled[1] != 9 || scrolled[2] != 0 {
+		t.Errorf("failed to notify List scroll changes: got %v", scrolled)
+	}
+}
+
+func TestListHeaders(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.AddHeader("File")
+	l.AddItem(NewListItem("Open"))
+	l.AddHeader("Edit")
+	l.AddItem(NewListItem("Copy"))
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	l.SetRect(0, 0, 30, 10)
+	l.Draw(app.screen)
+
+	// Headers are skipped when navigating.
+	handler := l.InputHandler()
+	down := tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	up := tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+	for _, test := range []struct {
+		event *tcell.EventKey
+		index int
+	}{
+		{down, 1},
+		{down, 3},
+		{up, 1},
+		{up, 1},
+	} {
+		handler(test.event, nil)
+		if index := l.GetCurrentItemIndex(); index != test.index {
+			t.Errorf("failed to skip List header: expected %d, got %d", test.index, index)
+		}
+	}
+
+	// Headers are drawn in their own style.
+	l.Draw(app.screen)
+	r, _, style, _ := app.screen.GetContent(0, 2)
+	if fg, _, attr := style.Decompose(); r != 'E' || fg != Styles.SecondaryTextColor || attr&tcell.AttrBold == 0 {
+		t.Errorf("failed to draw List header: got %c in %s", r, ColorHex(fg))
+	}
+}
+
+func TestListScrollMargin(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetScrollMargin(2)
+	for i := 0; i < 20; i++ {
+		l.AddItem(NewListItem(fmt.Sprintf("Item %d", i)))
+	}
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	l.SetRect(0, 0, 30, 10)
+	l.Draw(app.screen)
+
+	testCases := []struct {
+		item, offset int
+	}{
+		{8, 1},   // Two items remain below the selection.
+		{9, 2},   // Moving down scrolls by one item.
+		{5, 2},   // Items within the margins don't scroll.
+		{3, 1},   // Two items remain above the selection.
+		{1, 0},   // The margin doesn't apply at the beginning.
+		{19, 10}, // The margin doesn't apply at the end.
+	}
+	for _, c := range testCases {
+		l.SetCurrentItem(c.item)
+		if offset, _ := l.GetOffset(); offset != c.offset {
+			t.Errorf("failed to apply scroll margin when selecting item %d: expected offset %d, got %d", c.item, c.offset, offset)
+		}
+	}
+}
@@ -30,10 +30,27 @@ type Modal struct {
 	// The text alignment.
 	textAlign int
 
+	// The width of the window. A value of 0 means the width is determined
+	// automatically.
+	width int
+
+	// The maximum width of the message text. A value of 0 means no maximum.
+	textMaxWidth int
+
+	// The minimum height of the window.
+	minHeight int
+
 	// The optional callback for when the user clicked one of the buttons. It
 	// receives the index of the clicked button and the button's label.
 	done func(buttonIndex int, buttonLabel string)
 
+	// The input fields added with AddInputField and AddPasswordField.
+	inputFields []*InputField
+
+	// The optional callback for when the user clicked one of the buttons. It
+	// additionally receives the values of the input fields.
+	inputDone func(buttonIndex int, buttonLabel string, values []string)
+
 	sync.RWMutex
 }
 
@@ -49,9 +66,7 @@ func NewModal() *Modal {
 	m.form.SetButtonsAlign(AlignCenter)
 	m.form.SetPadding(0, 0, 0, 0)
 	m.form.SetCancelFunc(func() {
-		if m.done != nil {
-			m.done(-1, "")
-		}
+		m.finish(-1, "")
 	})
 
 	m.frame = NewFrame(m.form)
@@ -72,6 +87,23 @@ func (m *Modal) SetBackgroundColor(color tcell.Color) {
 	m.frame.SetBackgroundColor(color)
 }
 
+// SetShadow sets the flag indicating whether or not a shadow is drawn to the
+// bottom-right of the Modal Frame.
+func (m *Modal) SetShadow(shadow bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.frame.SetShadow(shadow)
+}
+
+// SetShadowColor sets the background color of the Modal Frame shadow.
+func (m *Modal) SetShadowColor(color tcell.Color) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.frame.SetShadowColor(color)
+}
+
 // SetTextColor sets the color of the message text.
 func (m *Modal) SetTextColor(color tcell.Color) {
 	m.Lock()
@@ -116,6 +148,65 @@ func (m *Modal) SetDoneFunc(handler func(buttonIndex int, buttonLabel string)) {
 	m.done = handler
 }
 
+// SetInputDoneFunc sets a handler which is called when one of the buttons was
+// pressed. In addition to the arguments provided to the handler set with
+// SetDoneFunc, it receives the values of the input fields added with
+// AddInputField and AddPasswordField, in the order they were added.
+func (m *Modal) SetInputDoneFunc(handler func(buttonIndex int, buttonLabel string, values []string)) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.inputDone = handler
+}
+
+// finish calls the done handlers.
+func (m *Modal) finish(buttonIndex int, buttonLabel string) {
+	m.RLock()
+	done := m.done
+	inputDone := m.inputDone
+	inputFields := m.inputFields
+	m.RUnlock()
+
+	if done != nil {
+		done(buttonIndex, buttonLabel)
+	}
+	if inputDone != nil {
+		values := make([]string, len(inputFields))
+		for i, inputField := range inputFields {
+			values[i] = inputField.GetText()
+		}
+		inputDone(buttonIndex, buttonLabel, values)
+	}
+}
+
+// AddInputField adds an input field with the provided label and placeholder
+// text to the window. Input fields are shown above the buttons. Their values
+// are provided to the handler set with SetInputDoneFunc.
+func (m *Modal) AddInputField(label, placeholder string) {
+	inputField := NewInputField()
+	inputField.SetLabel(label)
+	inputField.SetPlaceholder(placeholder)
+	m.addInputField(inputField)
+}
+
+// AddPasswordField adds an input field with the provided label and placeholder
+// text to the window which masks the entered text.
+func (m *Modal) AddPasswordField(label, placeholder string) {
+	inputField := NewInputField()
+	inputField.SetLabel(label)
+	inputField.SetPlaceholder(placeholder)
+	inputField.SetMaskCharacter('*')
+	m.addInputField(inputField)
+}
+
+func (m *Modal) addInputField(inputField *InputField) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.form.AddFormItem(inputField)
+	m.inputFields = append(m.inputFields, inputField)
+}
+
 // SetText sets the message text of the window. The text may contain line
 // breaks. Note that words are wrapped, too, based on the final size of the
 // window.
@@ -135,6 +226,35 @@ func (m *Modal) SetTextAlign(align int) {
 	m.textAlign = align
 }
 
+// SetWidth sets the width of the window, including its border. The window is
+// never wider than the screen. A value of 0 (the default) sizes the window
+// automatically based on the screen width and its content.
+func (m *Modal) SetWidth(width int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.width = width
+}
+
+// SetTextMaxWidth sets the maximum width of the message text. Longer lines are
+// wrapped. When the window is sized automatically, it is no wider than needed
+// to fit the text, the input fields and the buttons. A value of 0 (the
+// default) means no maximum.
+func (m *Modal) SetTextMaxWidth(width int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.textMaxWidth = width
+}
+
+// SetMinHeight sets the minimum height of the window, including its border.
+func (m *Modal) SetMinHeight(height int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.minHeight = height
+}
+
 // GetForm returns the Form embedded in the window. The returned Form may be
 // modified to include additional elements (e.g. AddInputField, AddFormItem).
 func (m *Modal) GetForm() *Form {
@@ -161,9 +281,7 @@ func (m *Modal) AddButtons(labels []string) {
 	for index, label := range labels {
 		func(i int, l string) {
 			m.form.AddButton(label, func() {
-				if m.done != nil {
-					m.done(i, l)
-				}
+				m.finish(i, l)
 			})
 			button := m.form.GetButton(m.form.GetButtonCount() - 1)
 			button.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -224,20 +342,53 @@ func (m *Modal) Draw(screen tcell.Screen) {
 	buttonsWidth -= 2
 	screenWidth, screenHeight := screen.Size()
 	width := screenWidth / 3
+	if m.textMaxWidth > 0 && width > m.textMaxWidth {
+		width = m.textMaxWidth
+	}
 	if width < buttonsWidth {
 		width = buttonsWidth
 	}
+	var labelWidth, fieldWidth int
+	for _, inputField := range m.inputFields {
+		if w := TaggedStringWidth(inputField.GetLabel()); w > labelWidth {
+			labelWidth = w
+		}
+		if w := TaggedStringWidth(inputField.GetPlaceholder()); w > fieldWidth {
+			fieldWidth = w
+		}
+	}
+	if len(m.inputFields) > 0 {
+		if fieldWidth < DefaultFormFieldWidth {
+			fieldWidth = DefaultFormFieldWidth
+		}
+		if width < labelWidth+1+fieldWidth {
+			width = labelWidth + 1 + fieldWidth
+		}
+	}
+	if m.width > 0 {
+		width = m.width - 4
+		if width > screenWidth-4 {
+			width = screenWidth - 4
+		}
+	}
 	// width is now without the box border.
 
 	// Reset the text and find out how wide it is.
 	m.frame.Clear()
-	lines := WordWrap(m.text, width)
+	textWidth := width
+	if m.textMaxWidth > 0 && textWidth > m.textMaxWidth {
+		textWidth = m.textMaxWidth
+	}
+	lines := WordWrap(m.text, textWidth)
 	for _, line := range lines {
 		m.frame.AddText(line, true, m.textAlign, m.textColor)
 	}
 
 	// Set the Modal's position and size.
 	height := len(lines) + (formItemCount * 2) + 6
+	if height < m.minHeight {
+		height = m.minHeight
+	}
 	width += 4
 	x := (screenWidth - width) / 2
 	y := (screenHeight - height) / 2
@@ -0,0 +1,82 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestModalInputFields(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText("Log in")
+	m.AddInputField("Username", "name")
+	m.AddPasswordField("Password", "")
+	m.AddButtons([]string{"OK", "Cancel"})
+
+	var (
+		doneIndex  = -2
+		doneValues []string
+	)
+	m.SetInputDoneFunc(func(buttonIndex int, buttonLabel string, values []string) {
+		doneIndex = buttonIndex
+		doneValues = values
+	})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	m.Draw(app.screen)
+
+	username := m.GetForm().GetFormItem(0).(*InputField)
+	password := m.GetForm().GetFormItem(1).(*InputField)
+	if _, _, width, _ := username.GetRect(); width < len("Username")+1+DefaultFormFieldWidth {
+		t.Errorf("failed to size modal for input fields: got field width %d", width)
+	}
+
+	username.SetText("user")
+	password.SetText("secret")
+	m.GetForm().GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	if doneIndex != 0 {
+		t.Errorf("failed to call done handler: expected button 0, got %d", doneIndex)
+	} else if len(doneValues) != 2 || doneValues[0] != "user" || doneValues[1] != "secret" {
+		t.Errorf("failed to provide input values: got %v", doneValues)
+	}
+}
+
+func TestModalSize(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText("The quick brown fox jumps over the lazy dog")
+	m.AddButtons([]string{"OK"})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	testCases := []struct {
+		width, textMaxWidth, minHeight int
+		x, y, w, h                     int
+	}{
+		{0, 0, 0, 25, 8, 30, 8},
+		{0, 10, 0, 33, 6, 14, 11},
+		{40, 0, 0, 20, 8, 40, 8},
+		{40, 10, 12, 20, 6, 40, 12},
+		{100, 0, 0, 0, 8, 80, 7},
+	}
+	for _, c := range testCases {
+		m.SetWidth(c.width)
+		m.SetTextMaxWidth(c.textMaxWidth)
+		m.SetMinHeight(c.minHeight)
+		m.Draw(app.screen)
+
+		x, y, w, h := m.GetRect()
+		if x != c.x || y != c.y || w != c.w || h != c.h {
+			t.Errorf("failed to size modal (width %d, text max width %d, min height %d): expected %d,%d %dx%d, got %d,%d %dx%d", c.width, c.textMaxWidth, c.minHeight, c.x, c.y, c.w, c.h, x, y, w, h)
+		}
+	}
+}
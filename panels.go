@@ -2,10 +2,21 @@ package cview
 
 import (
 	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// Panel transitions.
+const (
+	TransitionNone = iota
+	TransitionSlideLeft
+	TransitionSlideRight
+)
+
+// transitionFrameInterval is the time between frames of panel transitions.
+const transitionFrameInterval = time.Second / 30
+
 // panel represents a single panel of a Panels object.
 type panel struct {
 	Name    string    // The panel's name.
@@ -31,6 +42,25 @@ type Panels struct {
 	// panels changes.
 	changed func()
 
+	// The names of the panels which were visible before each call to
+	// PushPanel(), see PushPanel() for details.
+	stack [][]string
+
+	// The transition shown when switching panels, one of the Transition
+	// constants, and its duration.
+	transition         int
+	transitionDuration time.Duration
+
+	// The panels which were visible before the current transition started,
+	// or nil if no transition is in progress.
+	transitionFrom []*panel
+
+	// The time at which the current transition started.
+	transitionStart time.Time
+
+	// An optional handler which is called for each frame of a transition.
+	transitionDraw func()
+
 	sync.RWMutex
 }
 
@@ -201,6 +231,38 @@ func (p *Panels) HidePanel(name string) {
 	}
 }
 
+// SetTransition sets the transition shown when switching panels with
+// SetCurrentPanel(), one of the following:
+//
+//   - TransitionNone: Switch immediately (the default).
+//   - TransitionSlideLeft: Slide the new panel in from the right.
+//   - TransitionSlideRight: Slide the new panel in from the left.
+//
+// See SetTransitionDrawFunc() for redrawing the application while a transition
+// is in progress.
+func (p *Panels) SetTransition(transition int, duration time.Duration) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.transition = transition
+	p.transitionDuration = duration
+	if transition == TransitionNone || duration <= 0 {
+		p.transitionFrom = nil
+	}
+}
+
+// SetTransitionDrawFunc sets a handler which is called for each frame of a
+// transition. The handler is called from a separate goroutine and should
+// redraw the application, e.g. by calling Application.QueueUpdateDraw().
+// Without such a handler, transitions progress only when the panels are drawn
+// for other reasons.
+func (p *Panels) SetTransitionDrawFunc(handler func()) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.transitionDraw = handler
+}
+
 // SetCurrentPanel sets a panel's visibility to "true" and all other panels'
 // visibility to "false".
 func (p *Panels) SetCurrentPanel(name string) {
@@ -209,13 +271,74 @@ func (p *Panels) SetCurrentPanel(name string) {
 	p.Lock()
 	defer p.Unlock()
 
+	var hidden []*panel
 	for _, panel := range p.panels {
 		if panel.Name == name {
 			panel.Visible = true
 		} else {
+			if panel.Visible {
+				hidden = append(hidden, panel)
+			}
 			panel.Visible = false
 		}
 	}
+	if p.transition != TransitionNone && p.transitionDuration > 0 && len(hidden) > 0 {
+		p.startTransition(hidden)
+	}
+	if p.changed != nil {
+		p.Unlock()
+		p.changed()
+		p.Lock()
+	}
+	if hasFocus {
+		p.Unlock()
+		p.Focus(p.setFocus)
+		p.Lock()
+	}
+}
+
+// PushPanel shows the panel with the given name and hides all other panels,
+// like SetCurrentPanel(). The panels which were visible before are remembered
+// and shown again when PopPanel() is called.
+func (p *Panels) PushPanel(name string) {
+	p.Lock()
+	var visible []string
+	for _, panel := range p.panels {
+		if panel.Visible {
+			visible = append(visible, panel.Name)
+		}
+	}
+	p.stack = append(p.stack, visible)
+	p.Unlock()
+
+	p.SetCurrentPanel(name)
+}
+
+// PopPanel shows the panels which were visible before the last call to
+// PushPanel() and hides all other panels. If the panels had focus, the
+// front-most visible panel receives focus. Nothing happens if PushPanel() was
+// not called.
+func (p *Panels) PopPanel() {
+	hasFocus := p.HasFocus()
+
+	p.Lock()
+	defer p.Unlock()
+
+	if len(p.stack) == 0 {
+		return
+	}
+	visible := p.stack[len(p.stack)-1]
+	p.stack = p.stack[:len(p.stack)-1]
+
+	for _, panel := range p.panels {
+		panel.Visible = false
+		for _, name := range visible {
+			if panel.Name == name {
+				panel.Visible = true
+				break
+			}
+		}
+	}
 	if p.changed != nil {
 		p.Unlock()
 		p.changed()
@@ -228,6 +351,49 @@ func (p *Panels) SetCurrentPanel(name string) {
 	}
 }
 
+// GetStackSize returns the number of calls to PushPanel() which were not yet
+// followed by a call to PopPanel().
+func (p *Panels) GetStackSize() int {
+	p.RLock()
+	defer p.RUnlock()
+
+	return len(p.stack)
+}
+
+// startTransition starts a transition from the provided panels to the visible
+// panels. The caller must hold the lock.
+func (p *Panels) startTransition(from []*panel) {
+	start := time.Now()
+	duration := p.transitionDuration
+	p.transitionFrom = from
+	p.transitionStart = start
+	if p.transitionDraw == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(transitionFrameInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			p.RLock()
+			current := p.transitionStart.Equal(start) && p.transitionFrom != nil
+			draw := p.transitionDraw
+			p.RUnlock()
+
+			if !current {
+				return
+			}
+			if draw != nil {
+				draw()
+			}
+			if time.Since(start) >= duration {
+				return
+			}
+		}
+	}()
+}
+
 // SendToFront changes the order of the panels such that the panel with the given
 // name comes last, causing it to be drawn last with the next update (if
 // visible).
@@ -313,6 +479,18 @@ func (p *Panels) HasFocus() bool {
 	return false
 }
 
+// interceptKey passes a key event on to the focused panel.
+func (p *Panels) interceptKey(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	p.RLock()
+	items := make([]Primitive, len(p.panels))
+	for i, panel := range p.panels {
+		items[i] = panel.Item
+	}
+	p.RUnlock()
+
+	return interceptKey(event, setFocus, items...)
+}
+
 // Focus is called by the application when the primitive receives focus.
 func (p *Panels) Focus(delegate func(p Primitive)) {
 	p.Lock()
@@ -348,14 +526,55 @@ func (p *Panels) Draw(screen tcell.Screen) {
 
 	x, y, width, height := p.GetInnerRect()
 
-	for _, panel := range p.panels {
-		if !panel.Visible {
-			continue
+	// Determine the progress of the current transition.
+	var progress float64
+	if p.transitionFrom != nil {
+		elapsed := time.Since(p.transitionStart)
+		if elapsed >= p.transitionDuration || width <= 0 {
+			p.transitionFrom = nil
+		} else {
+			progress = float64(elapsed) / float64(p.transitionDuration)
 		}
+	}
+
+	if p.transitionFrom == nil {
+		for _, panel := range p.panels {
+			if !panel.Visible {
+				continue
+			}
+			if panel.Resize {
+				panel.Item.SetRect(x, y, width, height)
+			}
+			panel.Item.Draw(screen)
+		}
+		return
+	}
+
+	// Draw the transition.
+	shift := int(float64(width) * progress)
+	fromX, toX := -shift, width-shift
+	if p.transition == TransitionSlideRight {
+		fromX, toX = shift, shift-width
+	}
+	clipped := &clippedScreen{Screen: screen, x: x, y: y, width: width, height: height}
+	drawShifted := func(panel *panel, dx int) {
 		if panel.Resize {
-			panel.Item.SetRect(x, y, width, height)
+			panel.Item.SetRect(x+dx, y, width, height)
+			panel.Item.Draw(clipped)
+			return
+		}
+		px, py, pw, ph := panel.Item.GetRect()
+		panel.Item.SetRect(px+dx, py, pw, ph)
+		panel.Item.Draw(clipped)
+		panel.Item.SetRect(px, py, pw, ph)
+	}
+	for _, panel := range p.transitionFrom {
+		drawShifted(panel, fromX)
+	}
+	for _, panel := range p.panels {
+		if panel.Visible {
+			drawShifted(panel, toX)
 		}
-		panel.Item.Draw(screen)
 	}
 }
 
@@ -442,6 +661,18 @@ func (p *Pages) SwitchToPage(name string) {
 	p.SetCurrentPanel(name)
 }
 
+// PushPage shows the panel with the given name and hides all other panels.
+// The previously visible panels are shown again when PopPage() is called.
+func (p *Pages) PushPage(name string) {
+	p.PushPanel(name)
+}
+
+// PopPage shows the panels which were visible before the last call to
+// PushPage().
+func (p *Pages) PopPage() {
+	p.PopPanel()
+}
+
 // GetFrontPage returns the front-most visible panel.
 func (p *Pages) GetFrontPage() (name string, item Primitive) {
 	return p.GetFrontPanel()
@@ -0,0 +1,139 @@
+package cview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPanelsStack(t *testing.T) {
+	t.Parallel()
+
+	overlay := NewBox()
+
+	p := NewPanels()
+	p.AddPanel("main", NewBox(), true, true)
+	p.AddPanel("overlay", overlay, true, true)
+	p.AddPanel("step1", NewBox(), true, false)
+	p.AddPanel("step2", NewBox(), true, false)
+
+	app, err := newTestApp(p)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	frontPanel := func() string {
+		name, _ := p.GetFrontPanel()
+		return name
+	}
+
+	p.PushPanel("step1")
+	if frontPanel() != "step1" {
+		t.Errorf("failed to push panel: expected step1, got %s", frontPanel())
+	}
+	p.PushPanel("step2")
+	if frontPanel() != "step2" || p.GetStackSize() != 2 {
+		t.Errorf("failed to push panel: expected step2, got %s", frontPanel())
+	}
+
+	p.PopPanel()
+	if frontPanel() != "step1" {
+		t.Errorf("failed to pop panel: expected step1, got %s", frontPanel())
+	}
+	p.PopPanel()
+	if frontPanel() != "overlay" {
+		t.Errorf("failed to pop panel: expected overlay, got %s", frontPanel())
+	}
+	if !p.panels[0].Visible {
+		t.Errorf("failed to restore visibility of all panels")
+	}
+	if app.GetFocus() != overlay {
+		t.Errorf("failed to restore focus: expected overlay to have focus")
+	}
+
+	p.PopPanel()
+	if frontPanel() != "overlay" || p.GetStackSize() != 0 {
+		t.Errorf("failed to ignore pop of empty stack")
+	}
+}
+
+func TestPanelsTransition(t *testing.T) {
+	t.Parallel()
+
+	first := NewBox()
+	second := NewBox()
+
+	p := NewPanels()
+	p.AddPanel("first", first, true, true)
+	p.AddPanel("second", second, true, false)
+
+	app, err := newTestApp(p)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	p.SetRect(0, 0, 80, 24)
+	p.SetTransition(TransitionSlideLeft, time.Hour)
+	p.SetCurrentPanel("second")
+
+	// Draw halfway through the transition
+	p.Lock()
+	p.transitionStart = time.Now().Add(-30 * time.Minute)
+	p.Unlock()
+	p.Draw(app.screen)
+
+	if x, _, _, _ := first.GetRect(); x != -40 {
+		t.Errorf("failed to slide outgoing panel: expected x -40, got %d", x)
+	}
+	if x, _, _, _ := second.GetRect(); x != 40 {
+		t.Errorf("failed to slide incoming panel: expected x 40, got %d", x)
+	}
+
+	// Resize mid-transition
+	p.SetRect(0, 0, 40, 10)
+	p.Draw(app.screen)
+	if x, _, width, _ := second.GetRect(); x != 20 || width != 40 {
+		t.Errorf("failed to resize transition: expected x 20 width 40, got x %d width %d", x, width)
+	}
+
+	// Transition completes
+	p.Lock()
+	p.transitionStart = time.Now().Add(-2 * time.Hour)
+	p.Unlock()
+	p.Draw(app.screen)
+	if x, _, _, _ := second.GetRect(); x != 0 {
+		t.Errorf("failed to complete transition: expected x 0, got %d", x)
+	}
+
+	// Frames are drawn without notifying the changed handler
+	var changed int
+	p.SetChangedFunc(func() {
+		changed++
+	})
+	drawn := make(chan struct{}, 1)
+	p.SetTransitionDrawFunc(func() {
+		select {
+		case drawn <- struct{}{}:
+		default:
+		}
+	})
+	p.SetTransition(TransitionSlideRight, 100*time.Millisecond)
+	p.SetCurrentPanel("first")
+	select {
+	case <-drawn:
+	case <-time.After(time.Second):
+		t.Errorf("failed to draw transition frames")
+	}
+	p.SetChangedFunc(nil)
+	if changed != 1 {
+		t.Errorf("failed to notify changed handler once: got %d calls", changed)
+	}
+
+	// No transition
+	p.SetTransition(TransitionNone, 0)
+	p.SetCurrentPanel("second")
+	p.SetCurrentPanel("first")
+	p.Draw(app.screen)
+	if x, _, _, _ := first.GetRect(); x != 0 {
+		t.Errorf("failed to switch without transition: expected x 0, got %d", x)
+	}
+}
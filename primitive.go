@@ -61,3 +61,38 @@ type Primitive interface {
 	// Box.WrapMouseHandler() so you inherit that functionality.
 	MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive)
 }
+
+// PastePrimitive is implemented by primitives which handle pasted text at
+// once rather than as a key event for each character.
+type PastePrimitive interface {
+	Primitive
+
+	// PasteHandler returns a handler which receives pasted text when the
+	// primitive has focus. It is called by the Application class when the
+	// terminal reports a bracketed paste, or when several characters are
+	// received in quick succession.
+	PasteHandler() func(text string, setFocus func(p Primitive))
+}
+
+// keyInterceptor is implemented by container primitives which may handle key
+// events before they are passed to the focused primitive within them.
+type keyInterceptor interface {
+	// interceptKey returns whether the key event was handled.
+	interceptKey(event *tcell.EventKey, setFocus func(p Primitive)) bool
+}
+
+// interceptKey passes a key event on to the first of the provided primitives
+// which has focus, if it is a key interceptor. It returns whether the key event
+// was handled.
+func interceptKey(event *tcell.EventKey, setFocus func(p Primitive), items ...Primitive) bool {
+	for _, item := range items {
+		if item == nil || !item.GetFocusable().HasFocus() {
+			continue
+		}
+		if interceptor, ok := item.(keyInterceptor); ok {
+			return interceptor.interceptKey(event, setFocus)
+		}
+		return false
+	}
+	return false
+}
@@ -1,10 +1,13 @@
 package cview
 
 import (
+	"fmt"
 	"math"
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/mattn/go-runewidth"
 )
 
 // ProgressBar indicates the progress of an operation.
@@ -23,6 +26,10 @@ type ProgressBar struct {
 	// Color of the filled area of the progress bar.
 	filledColor tcell.Color
 
+	// Colors of the filled area of the progress bar, interpolated from the
+	// start to the end of the bar. When empty, filledColor is used.
+	gradient []tcell.Color
+
 	// If set to true, instead of filling from left to right, the bar is filled
 	// from bottom to top.
 	vertical bool
@@ -33,6 +40,18 @@ type ProgressBar struct {
 	// Progress required to fill the bar.
 	max int
 
+	// Whether or not the progress is indeterminate.
+	indeterminate bool
+
+	// The number of times the bar was drawn in indeterminate mode.
+	indeterminateFrame int
+
+	// Text drawn centered on top of the bar.
+	label string
+
+	// Whether or not the percentage is drawn on top of the bar.
+	showPercentage bool
+
 	sync.RWMutex
 }
 
@@ -82,6 +101,18 @@ func (p *ProgressBar) SetFilledColor(filled tcell.Color) {
 	p.filledColor = filled
 }
 
+// SetGradient sets the colors of the filled area of the progress bar. Each
+// filled cell is drawn in a color interpolated between the provided colors
+// based on its position within the bar, so the first color is shown at the
+// start of the bar and the last color is shown when the bar is full. Pass nil
+// to use the color set with SetFilledColor.
+func (p *ProgressBar) SetGradient(colors []tcell.Color) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.gradient = colors
+}
+
 // SetVertical sets the direction of the progress bar.
 func (p *ProgressBar) SetVertical(vertical bool) {
 	p.Lock()
@@ -140,6 +171,45 @@ func (p *ProgressBar) GetProgress() int {
 	return p.progress
 }
 
+// SetIndeterminate sets whether or not the progress is indeterminate. When
+// the progress is indeterminate, a small block moves back and forth across the
+// bar each time it is drawn.
+func (p *ProgressBar) SetIndeterminate(indeterminate bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.indeterminate = indeterminate
+	p.indeterminateFrame = 0
+}
+
+// GetIndeterminate returns whether or not the progress is indeterminate.
+func (p *ProgressBar) GetIndeterminate() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.indeterminate
+}
+
+// SetLabel sets the text drawn centered on top of the progress bar. The text
+// is drawn in the background color over the filled area and in the filled
+// color over the empty area.
+func (p *ProgressBar) SetLabel(label string) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.label = label
+}
+
+// SetShowPercentage sets whether or not the percentage of the progress is
+// drawn on top of the progress bar, after the label. The percentage is not
+// shown while the progress is indeterminate.
+func (p *ProgressBar) SetShowPercentage(showPercentage bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.showPercentage = showPercentage
+}
+
 // Complete returns whether the progress bar has been filled.
 func (p *ProgressBar) Complete() bool {
 	p.RLock()
@@ -168,25 +238,99 @@ func (p *ProgressBar) Draw(screen tcell.Screen) {
 		maxLength = height
 	}
 
+	var barStart int
 	barLength := int(math.RoundToEven(float64(maxLength) * (float64(p.progress) / float64(p.max))))
+	if p.indeterminate {
+		barStart, barLength = p.indeterminateBar(maxLength)
+		p.indeterminateFrame++
+	}
 	if barLength > maxLength {
 		barLength = maxLength
 	}
 
 	for i := 0; i < barSize; i++ {
-		for j := 0; j < barLength; j++ {
-			if p.vertical {
-				screen.SetContent(x+i, y+(height-1-j), p.filledRune, nil, tcell.StyleDefault.Foreground(p.filledColor).Background(p.backgroundColor))
-			} else {
-				screen.SetContent(x+j, y+i, p.filledRune, nil, tcell.StyleDefault.Foreground(p.filledColor).Background(p.backgroundColor))
+		for j := 0; j < maxLength; j++ {
+			r, color := p.emptyRune, p.emptyColor
+			if j >= barStart && j < barStart+barLength {
+				r, color = p.filledRune, p.gradientColor(j, maxLength)
 			}
-		}
-		for j := barLength; j < maxLength; j++ {
 			if p.vertical {
-				screen.SetContent(x+i, y+(height-1-j), p.emptyRune, nil, tcell.StyleDefault.Foreground(p.emptyColor).Background(p.backgroundColor))
+				screen.SetContent(x+i, y+(height-1-j), r, nil, tcell.StyleDefault.Foreground(color).Background(p.backgroundColor))
 			} else {
-				screen.SetContent(x+j, y+i, p.emptyRune, nil, tcell.StyleDefault.Foreground(p.emptyColor).Background(p.backgroundColor))
+				screen.SetContent(x+j, y+i, r, nil, tcell.StyleDefault.Foreground(color).Background(p.backgroundColor))
 			}
 		}
 	}
+
+	// Draw label.
+	label := p.label
+	if p.showPercentage && !p.indeterminate && p.max > 0 {
+		if label != "" {
+			label += " "
+		}
+		label += fmt.Sprintf("%d%%", p.progress*100/p.max)
+	}
+	if label == "" || width <= 0 || height <= 0 {
+		return
+	}
+	labelWidth := runewidth.StringWidth(label)
+	if labelWidth > width {
+		label = runewidth.Truncate(label, width, "")
+		labelWidth = runewidth.StringWidth(label)
+	}
+	labelX, labelY := x+(width-labelWidth)/2, y+height/2
+	for _, r := range label {
+		j := labelX - x
+		if p.vertical {
+			j = height - 1 - (labelY - y)
+		}
+		style := tcell.StyleDefault.Foreground(p.gradientColor(j, maxLength)).Background(p.backgroundColor)
+		if j >= barStart && j < barStart+barLength {
+			style = tcell.StyleDefault.Foreground(p.backgroundColor).Background(p.gradientColor(j, maxLength))
+		}
+		screen.SetContent(labelX, labelY, r, nil, style)
+		labelX += runewidth.RuneWidth(r)
+	}
+}
+
+// gradientColor returns the color of the filled cell at the provided position.
+// The caller must hold the lock.
+func (p *ProgressBar) gradientColor(position int, maxLength int) tcell.Color {
+	if len(p.gradient) == 0 {
+		return p.filledColor
+	} else if len(p.gradient) == 1 || maxLength <= 1 {
+		return p.gradient[0]
+	}
+
+	offset := float64(position) / float64(maxLength-1) * float64(len(p.gradient)-1)
+	i := int(offset)
+	if i >= len(p.gradient)-1 {
+		return p.gradient[len(p.gradient)-1]
+	}
+
+	r, g, b := p.gradient[i].RGB()
+	from := colorful.Color{R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255}
+	r, g, b = p.gradient[i+1].RGB()
+	to := colorful.Color{R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255}
+	cr, cg, cb := from.BlendRgb(to, offset-float64(i)).Clamped().RGB255()
+	return tcell.NewRGBColor(int32(cr), int32(cg), int32(cb))
+}
+
+// indeterminateBar returns the start and length of the block drawn in
+// indeterminate mode. The caller must hold the lock.
+func (p *ProgressBar) indeterminateBar(maxLength int) (start int, length int) {
+	length = maxLength / 5
+	if length < 1 {
+		length = 1
+	}
+	span := maxLength - length
+	if span <= 0 {
+		return 0, length
+	}
+
+	start = p.indeterminateFrame % (span * 2)
+	if start > span {
+		start = span*2 - start
+	}
+	return start, length
 }
@@ -2,6 +2,8 @@ package cview
 
 import (
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 func TestProgressBar(t *testing.T) {
@@ -66,3 +68,117 @@ func TestProgressBar(t *testing.T) {
 
 	p.Draw(app.screen)
 }
+
+func TestProgressBarIndeterminate(t *testing.T) {
+	t.Parallel()
+
+	p := NewProgressBar()
+	p.SetFilledRune('#')
+	p.SetEmptyRune('-')
+	p.SetRect(0, 0, 10, 1)
+
+	app, err := newTestApp(p)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	bar := func() string {
+		p.Draw(app.screen)
+		var b []rune
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			b = append(b, r)
+		}
+		return string(b)
+	}
+
+	p.SetProgress(50)
+	p.SetIndeterminate(true)
+	expected := []string{"##--------", "-##-------"}
+	for i, e := range expected {
+		if b := bar(); b != e {
+			t.Errorf("failed to draw indeterminate ProgressBar frame %d: expected %s, got %s", i, e, b)
+		}
+	}
+	for i := 0; i < 6; i++ {
+		bar()
+	}
+	expected = []string{"--------##", "-------##-"}
+	for i, e := range expected {
+		if b := bar(); b != e {
+			t.Errorf("failed to bounce indeterminate ProgressBar frame %d: expected %s, got %s", i, e, b)
+		}
+	}
+
+	p.SetIndeterminate(false)
+	if b := bar(); b != "#####-----" {
+		t.Errorf("failed to draw determinate ProgressBar: expected #####-----, got %s", b)
+	}
+}
+
+func TestProgressBarGradient(t *testing.T) {
+	t.Parallel()
+
+	p := NewProgressBar()
+	p.SetRect(0, 0, 5, 1)
+	p.SetGradient([]tcell.Color{tcell.NewRGBColor(0, 0, 0), tcell.NewRGBColor(200, 0, 0), tcell.NewRGBColor(200, 200, 0)})
+	p.SetProgress(60)
+
+	app, err := newTestApp(p)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	p.Draw(app.screen)
+
+	expected := []tcell.Color{tcell.NewRGBColor(0, 0, 0), tcell.NewRGBColor(100, 0, 0), tcell.NewRGBColor(200, 0, 0)}
+	for x, e := range expected {
+		_, _, style, _ := app.screen.GetContent(x, 0)
+		if fg, _, _ := style.Decompose(); fg != e {
+			t.Errorf("failed to draw gradient at %d: expected %s, got %s", x, ColorHex(e), ColorHex(fg))
+		}
+	}
+	_, _, style, _ := app.screen.GetContent(3, 0)
+	if fg, _, _ := style.Decompose(); fg != Styles.PrimitiveBackgroundColor {
+		t.Errorf("failed to draw empty area: expected %s, got %s", ColorHex(Styles.PrimitiveBackgroundColor), ColorHex(fg))
+	}
+}
+
+func TestProgressBarLabel(t *testing.T) {
+	t.Parallel()
+
+	p := NewProgressBar()
+	p.SetRect(0, 0, 10, 1)
+	p.SetFilledColor(tcell.ColorWhite.TrueColor())
+	p.SetProgress(42)
+	p.SetShowPercentage(true)
+
+	app, err := newTestApp(p)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	p.Draw(app.screen)
+
+	for i, e := range "42%" {
+		r, _, style, _ := app.screen.GetContent(3+i, 0)
+		fg, bg, _ := style.Decompose()
+		if r != e {
+			t.Errorf("failed to draw label at %d: expected %c, got %c", 3+i, e, r)
+		}
+		if i == 0 && (fg != Styles.PrimitiveBackgroundColor || bg != tcell.ColorWhite.TrueColor()) {
+			t.Errorf("failed to draw label over filled area: got %s on %s", ColorHex(fg), ColorHex(bg))
+		} else if i > 0 && (fg != tcell.ColorWhite.TrueColor() || bg != Styles.PrimitiveBackgroundColor) {
+			t.Errorf("failed to draw label over empty area: got %s on %s", ColorHex(fg), ColorHex(bg))
+		}
+	}
+
+	p.SetLabel("Copying")
+	p.Draw(app.screen)
+	var text []rune
+	for x := 0; x < 10; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 0)
+		text = append(text, r)
+	}
+	if string(text) != "Copying 42" {
+		t.Errorf("failed to draw label: expected Copying 42, got %s", string(text))
+	}
+}
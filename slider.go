@@ -39,13 +39,23 @@ type Slider struct {
 	// The amount to increment by when modified via keyboard.
 	increment int
 
+	// The amount to increment by when PageUp or PageDown is pressed.
+	pageIncrement int
+
 	// Set to true when mouse dragging is in progress.
 	dragging bool
 
+	// The position and size of the bar the last time it was drawn.
+	barX, barY, barWidth, barHeight int
+
 	// An optional function which is called when the user changes the value of
 	// this slider.
 	changed func(value int)
 
+	// An optional function which notifies the form this item belongs to when
+	// the value was changed by the user.
+	formChanged func()
+
 	// An optional function which is called when the user indicated that they
 	// are done entering text. The key which was pressed is provided (tab,
 	// shift-tab, or escape).
@@ -63,6 +73,7 @@ func NewSlider() *Slider {
 	s := &Slider{
 		ProgressBar:                 NewProgressBar(),
 		increment:                   10,
+		pageIncrement:               25,
 		labelColor:                  Styles.SecondaryTextColor,
 		fieldBackgroundColor:        Styles.MoreContrastBackgroundColor,
 		fieldBackgroundColorFocused: Styles.ContrastBackgroundColor,
@@ -146,6 +157,12 @@ func (s *Slider) SetFieldTextColorFocused(color tcell.Color) {
 	s.fieldTextColorFocused = color
 }
 
+// SetVertical sets whether the slider runs from bottom to top instead of from
+// left to right. The label of a vertical slider is drawn below it.
+func (s *Slider) SetVertical(vertical bool) {
+	s.ProgressBar.SetVertical(vertical)
+}
+
 // GetFieldHeight returns the height of the field.
 func (s *Slider) GetFieldHeight() int {
 	return 1
@@ -165,6 +182,15 @@ func (s *Slider) SetIncrement(increment int) {
 	s.increment = increment
 }
 
+// SetPageIncrement sets the amount the slider is incremented by when PageUp or
+// PageDown is pressed.
+func (s *Slider) SetPageIncrement(increment int) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.pageIncrement = increment
+}
+
 // SetChangedFunc sets a handler which is called when the value of this slider
 // was changed by the user. The handler function receives the new value.
 func (s *Slider) SetChangedFunc(handler func(value int)) {
@@ -174,6 +200,15 @@ func (s *Slider) SetChangedFunc(handler func(value int)) {
 	s.changed = handler
 }
 
+// setFormChangedFunc sets a handler which is called when the value of this
+// slider was changed by the user. It is used by Form.
+func (s *Slider) setFormChangedFunc(handler func()) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.formChanged = handler
+}
+
 // SetDoneFunc sets a handler which is called when the user is done using the
 // slider. The callback function is provided with the key that was pressed,
 // which is one of the following:
@@ -234,9 +269,10 @@ func (s *Slider) Draw(screen tcell.Screen) {
 	// Draw label.
 	if len(s.label) > 0 {
 		if s.vertical {
-			height--
-
-			// TODO draw label on bottom
+			if height > 1 {
+				height--
+				Print(screen, []byte(s.label), x, y+height, width, AlignLeft, labelColor)
+			}
 		} else {
 			if s.labelWidth > 0 {
 				labelWidth := s.labelWidth
@@ -254,12 +290,16 @@ func (s *Slider) Draw(screen tcell.Screen) {
 		}
 	}
 
-	// Draw slider.
+	// Draw slider. The bar shares the slider's box, so its rectangle is only
+	// changed while the bar is drawn.
+	s.barX, s.barY, s.barWidth, s.barHeight = x, y, width, height
 	s.Unlock()
+	rectX, rectY, rectWidth, rectHeight := s.GetRect()
 	s.ProgressBar.SetRect(x, y, width, height)
 	s.ProgressBar.SetEmptyColor(fieldBackgroundColor)
 	s.ProgressBar.SetFilledColor(fieldTextColor)
 	s.ProgressBar.Draw(screen)
+	s.ProgressBar.SetRect(rectX, rectY, rectWidth, rectHeight)
 }
 
 // InputHandler returns the handler for this primitive.
@@ -285,10 +325,19 @@ func (s *Slider) InputHandler() func(event *tcell.EventKey, setFocus func(p Prim
 			s.AddProgress(s.increment)
 		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2, Keys.MoveLeft, Keys.MoveLeft2, Keys.MoveNextField) {
 			s.AddProgress(s.increment * -1)
+		} else if HitShortcut(event, Keys.MovePreviousPage) {
+			s.AddProgress(s.pageIncrement)
+		} else if HitShortcut(event, Keys.MoveNextPage) {
+			s.AddProgress(s.pageIncrement * -1)
 		}
 
-		if s.progress != previous && s.changed != nil {
-			s.changed(s.progress)
+		if s.progress != previous {
+			if s.changed != nil {
+				s.changed(s.progress)
+			}
+			if s.formChanged != nil {
+				s.formChanged()
+			}
 		}
 	})
 }
@@ -309,12 +358,14 @@ func (s *Slider) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 		}
 
 		handleMouse := func() {
-			if !s.ProgressBar.InRect(x, y) {
+			s.RLock()
+			bx, by, bw, bh := s.barX, s.barY, s.barWidth, s.barHeight
+			s.RUnlock()
+			if x < bx || x >= bx+bw || y < by || y >= by+bh {
 				s.dragging = false
 				return
 			}
 
-			bx, by, bw, bh := s.GetInnerRect()
 			var clickPos, clickRange int
 			if s.ProgressBar.vertical {
 				clickPos = (bh - 1) - (y - by)
@@ -323,12 +374,18 @@ func (s *Slider) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 				clickPos = x - bx
 				clickRange = bw - 1
 			}
+			if clickRange <= 0 {
+				clickPos, clickRange = 1, 1
+			}
 			setValue := int(math.Floor(float64(s.max) * (float64(clickPos) / float64(clickRange))))
 			if setValue != s.progress {
 				s.SetProgress(setValue)
 				if s.changed != nil {
 					s.changed(s.progress)
 				}
+				if s.formChanged != nil {
+					s.formChanged()
+				}
 			}
 		}
 
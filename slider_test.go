@@ -0,0 +1,85 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSliderIncrement(t *testing.T) {
+	t.Parallel()
+
+	s := NewSlider()
+	s.SetIncrement(5)
+	s.SetPageIncrement(30)
+
+	var changed []int
+	s.SetChangedFunc(func(value int) {
+		changed = append(changed, value)
+	})
+
+	inputHandler := s.InputHandler()
+	keys := []tcell.Key{tcell.KeyRight, tcell.KeyPgUp, tcell.KeyPgUp, tcell.KeyPgUp, tcell.KeyPgUp, tcell.KeyLeft, tcell.KeyPgDn, tcell.KeyHome, tcell.KeyEnd}
+	for _, key := range keys {
+		inputHandler(tcell.NewEventKey(key, 0, tcell.ModNone), nil)
+	}
+
+	expected := []int{5, 35, 65, 95, 100, 95, 65, 0, 100}
+	if len(changed) != len(expected) {
+		t.Fatalf("failed to change Slider: expected %v, got %v", expected, changed)
+	}
+	for i := range expected {
+		if changed[i] != expected[i] {
+			t.Fatalf("failed to change Slider: expected %v, got %v", expected, changed)
+		}
+	}
+}
+
+func TestSliderVertical(t *testing.T) {
+	t.Parallel()
+
+	s := NewSlider()
+	s.SetVertical(true)
+	s.SetLabel("Vol")
+
+	var changed []int
+	s.SetChangedFunc(func(value int) {
+		changed = append(changed, value)
+	})
+
+	app, err := newTestApp(s)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	s.SetRect(0, 0, 3, 6)
+	s.Draw(app.screen)
+	s.Draw(app.screen)
+
+	if _, _, width, height := s.GetRect(); width != 3 || height != 6 {
+		t.Errorf("failed to keep Slider size: expected 3x6, got %dx%d", width, height)
+	}
+	if r, _, _, _ := app.screen.GetContent(0, 5); r != 'V' {
+		t.Errorf("failed to draw Slider label below bar: got %c", r)
+	}
+
+	// The bar occupies rows 0 to 4, from bottom to top.
+	handler := s.MouseHandler()
+	for _, y := range []int{4, 2, 0, 5} {
+		handler(MouseLeftDown, tcell.NewEventMouse(1, y, tcell.Button1, tcell.ModNone), func(p Primitive) {})
+		handler(MouseLeftUp, tcell.NewEventMouse(1, y, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {})
+	}
+
+	inputHandler := s.InputHandler()
+	inputHandler(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), nil)
+	inputHandler(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), nil)
+
+	expected := []int{50, 100, 90, 100}
+	if len(changed) != len(expected) {
+		t.Fatalf("failed to change vertical Slider: expected %v, got %v", expected, changed)
+	}
+	for i := range expected {
+		if changed[i] != expected[i] {
+			t.Fatalf("failed to change vertical Slider: expected %v, got %v", expected, changed)
+		}
+	}
+}
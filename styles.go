@@ -1,6 +1,15 @@
 package cview
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // Theme defines the colors used when primitives are initialized.
 type Theme struct {
@@ -26,14 +35,16 @@ type Theme struct {
 	ButtonCursorRune rune // The symbol to draw at the end of button labels when focused.
 
 	// Check box
-	CheckBoxCheckedRune rune
-	CheckBoxCursorRune  rune // The symbol to draw within the checkbox when focused.
+	CheckBoxCheckedRune       rune
+	CheckBoxIndeterminateRune rune // The symbol to draw when only some options are checked.
+	CheckBoxCursorRune        rune // The symbol to draw within the checkbox when focused.
 
 	// Context menu
 	ContextMenuPaddingTop    int
 	ContextMenuPaddingBottom int
 	ContextMenuPaddingLeft   int
 	ContextMenuPaddingRight  int
+	ContextMenuSubMenuRune   rune // The symbol to draw after items which open a submenu.
 
 	// Drop down
 	DropDownAbbreviationChars string // The chars to show when the option's text gets shortened.
@@ -44,6 +55,9 @@ type Theme struct {
 	// Scroll bar
 	ScrollBarColor tcell.Color
 
+	// Tabbed panels
+	TabCloseRune rune // The symbol to draw after closeable tab labels.
+
 	// Window
 	WindowMinWidth  int
 	WindowMinHeight int
@@ -70,13 +84,15 @@ var Styles = Theme{
 
 	ButtonCursorRune: '◀',
 
-	CheckBoxCheckedRune: 'X',
-	CheckBoxCursorRune:  '◀',
+	CheckBoxCheckedRune:       'X',
+	CheckBoxIndeterminateRune: '-',
+	CheckBoxCursorRune:        '◀',
 
 	ContextMenuPaddingTop:    0,
 	ContextMenuPaddingBottom: 0,
 	ContextMenuPaddingLeft:   1,
 	ContextMenuPaddingRight:  1,
+	ContextMenuSubMenuRune:   '▶',
 
 	DropDownAbbreviationChars: "...",
 	DropDownSymbol:            '◀',
@@ -85,6 +101,88 @@ var Styles = Theme{
 
 	ScrollBarColor: tcell.ColorWhite.TrueColor(),
 
+	TabCloseRune: 'x',
+
 	WindowMinWidth:  4,
 	WindowMinHeight: 3,
 }
+
+// ThemeWarningError is returned by Theme.LoadJSON when a theme was loaded but
+// some of its entries were ignored.
+type ThemeWarningError struct {
+	Warnings []string
+}
+
+// Error returns the collected warnings.
+func (e *ThemeWarningError) Error() string {
+	return "theme loaded with warnings: " + strings.Join(e.Warnings, "; ")
+}
+
+// colorFields returns the color fields of the theme by name.
+func (t *Theme) colorFields() map[string]*tcell.Color {
+	fields := make(map[string]*tcell.Color)
+	v := reflect.ValueOf(t).Elem()
+	colorType := reflect.TypeOf(tcell.ColorDefault)
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).Type() == colorType {
+			fields[v.Type().Field(i).Name] = v.Field(i).Addr().Interface().(*tcell.Color)
+		}
+	}
+	return fields
+}
+
+// LoadJSON loads the colors of the theme from a JSON object which maps the
+// names of color fields (e.g. "PrimaryTextColor") to color names or
+// hexadecimal values (e.g. "white" or "#ffffff"). Fields not present in the
+// object are left unchanged. Unknown fields and invalid colors are ignored and
+// reported by returning a *ThemeWarningError after the remaining colors have
+// been loaded.
+func (t *Theme) LoadJSON(r io.Reader) error {
+	var values map[string]string
+	if err := json.NewDecoder(r).Decode(&values); err != nil {
+		return fmt.Errorf("failed to decode theme: %s", err)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var warnings []string
+	fields := t.colorFields()
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown field %s", name))
+			continue
+		}
+
+		color := tcell.GetColor(values[name])
+		if color == tcell.ColorDefault {
+			warnings = append(warnings, fmt.Sprintf("invalid color %s for field %s", values[name], name))
+			continue
+		}
+		*field = color
+	}
+	if len(warnings) > 0 {
+		return &ThemeWarningError{Warnings: warnings}
+	}
+	return nil
+}
+
+// SaveJSON saves the colors of the theme as a JSON object which maps the names
+// of color fields to hexadecimal values. Unset colors are omitted. See
+// LoadJSON.
+func (t *Theme) SaveJSON(w io.Writer) error {
+	values := make(map[string]string)
+	for name, field := range t.colorFields() {
+		if hex := ColorHex(*field); hex != "" {
+			values[name] = hex
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(values)
+}
@@ -0,0 +1,46 @@
+package cview
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestThemeJSON(t *testing.T) {
+	t.Parallel()
+
+	theme := Styles
+	err := theme.LoadJSON(strings.NewReader(`{"PrimaryTextColor": "red", "BorderColor": "#00ff00", "Unknown": "blue", "TitleColor": "nope"}`))
+
+	var warning *ThemeWarningError
+	if !errors.As(err, &warning) || len(warning.Warnings) != 2 {
+		t.Errorf("failed to collect theme warnings: got %v", err)
+	}
+	if theme.PrimaryTextColor != tcell.ColorRed {
+		t.Errorf("failed to load named color: got %s", ColorHex(theme.PrimaryTextColor))
+	} else if theme.BorderColor != tcell.NewHexColor(0x00ff00) {
+		t.Errorf("failed to load hex color: got %s", ColorHex(theme.BorderColor))
+	} else if theme.TitleColor != Styles.TitleColor {
+		t.Errorf("failed to ignore invalid color: got %s", ColorHex(theme.TitleColor))
+	}
+
+	var b bytes.Buffer
+	if err := theme.SaveJSON(&b); err != nil {
+		t.Fatalf("failed to save theme: %s", err)
+	}
+
+	loaded := Styles
+	if err := loaded.LoadJSON(&b); err != nil {
+		t.Fatalf("failed to load saved theme: %s", err)
+	}
+	if ColorHex(loaded.PrimaryTextColor) != ColorHex(theme.PrimaryTextColor) || ColorHex(loaded.BorderColor) != ColorHex(theme.BorderColor) {
+		t.Errorf("failed to restore saved theme")
+	}
+
+	if err := loaded.LoadJSON(strings.NewReader(`{`)); err == nil || errors.As(err, &warning) {
+		t.Errorf("failed to reject invalid JSON: got %v", err)
+	}
+}
@@ -3,13 +3,22 @@ package cview
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// tabCloseRegionPrefix is the prefix of the region IDs of tab close buttons.
+const tabCloseRegionPrefix = "close:"
+
 // TabbedPanels is a tabbed container for other primitives. The tab switcher
 // may be positioned vertically or horizontally, before or after the content.
+//
+// While the tabbed panels or their contents have focus, the previous or next
+// tab is shown when Keys.PreviousTab or Keys.NextTab is pressed. This requires
+// the tabbed panels to be the application's root or to be placed within
+// layout primitives such as Flex, Grid, Panels or Frame.
 type TabbedPanels struct {
 	*Flex
 	Switcher *TextView
@@ -28,6 +37,17 @@ type TabbedPanels struct {
 
 	width, lastWidth int
 
+	// Whether or not tabs may be closed by clicking on the close rune, and
+	// overrides of this setting for individual tabs.
+	tabsCloseable bool
+	tabCloseable  map[string]bool
+
+	// The rune drawn after the labels of closeable tabs.
+	closeRune rune
+
+	// An optional function which is called when the user closes a tab.
+	tabClosed func(name string)
+
 	setFocus func(Primitive)
 
 	sync.RWMutex
@@ -42,6 +62,9 @@ func NewTabbedPanels() *TabbedPanels {
 		dividerMid: string(BoxDrawingsDoubleVertical),
 		dividerEnd: string(BoxDrawingsLightVertical),
 		tabLabels:  make(map[string]string),
+
+		tabCloseable: make(map[string]bool),
+		closeRune:    Styles.TabCloseRune,
 	}
 
 	s := t.Switcher
@@ -64,6 +87,14 @@ func NewTabbedPanels() *TabbedPanels {
 		}
 	})
 
+	s.SetClickedFunc(func(regionID string) {
+		if strings.HasPrefix(regionID, tabCloseRegionPrefix) {
+			t.closeTab(strings.TrimPrefix(regionID, tabCloseRegionPrefix))
+			return
+		}
+		s.Highlight(regionID)
+	})
+
 	t.rebuild()
 
 	return t
@@ -94,6 +125,82 @@ func (t *TabbedPanels) RemoveTab(name string) {
 	t.updateAll()
 }
 
+// SetTabsCloseable sets whether or not tabs may be closed by clicking on the
+// close rune drawn after their labels. This may be overridden for individual
+// tabs with SetTabCloseable.
+func (t *TabbedPanels) SetTabsCloseable(closeable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.tabsCloseable = closeable
+	t.updateTabLabels()
+}
+
+// SetTabCloseable sets whether or not the tab with the given name may be
+// closed, overriding the setting of SetTabsCloseable.
+func (t *TabbedPanels) SetTabCloseable(name string, closeable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.tabCloseable[name] = closeable
+	t.updateTabLabels()
+}
+
+// SetTabClosedFunc sets a handler which is called when the user closes a tab.
+// The handler receives the name of the closed tab.
+func (t *TabbedPanels) SetTabClosedFunc(handler func(name string)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.tabClosed = handler
+}
+
+// isTabCloseable returns whether or not the tab with the given name may be
+// closed. The caller must hold the lock.
+func (t *TabbedPanels) isTabCloseable(name string) bool {
+	if closeable, ok := t.tabCloseable[name]; ok {
+		return closeable
+	}
+	return t.tabsCloseable
+}
+
+// closeTab removes the tab with the given name when it may be closed. When the
+// current tab is closed, the adjacent tab is selected.
+func (t *TabbedPanels) closeTab(name string) {
+	t.Lock()
+	if !t.isTabCloseable(name) {
+		t.Unlock()
+		return
+	}
+	var next string
+	if t.currentTab == name {
+		panels := t.panels.panels
+		for i, panel := range panels {
+			if panel.Name != name {
+				continue
+			}
+			if i < len(panels)-1 {
+				next = panels[i+1].Name
+			} else if i > 0 {
+				next = panels[i-1].Name
+			}
+			break
+		}
+	}
+	delete(t.tabCloseable, name)
+	tabClosed := t.tabClosed
+	t.Unlock()
+
+	if next != "" {
+		t.SetCurrentTab(next)
+	}
+	t.RemoveTab(name)
+
+	if tabClosed != nil {
+		tabClosed(name)
+	}
+}
+
 // HasTab returns true if a tab with the given name exists in this object.
 func (t *TabbedPanels) HasTab(name string) bool {
 	t.RLock()
@@ -251,11 +358,15 @@ func (t *TabbedPanels) updateTabLabels() {
 	}
 
 	maxWidth := 0
+	var closeable bool
 	for _, panel := range t.panels.panels {
 		label := t.tabLabels[panel.Name]
 		if len(label) > maxWidth {
 			maxWidth = len(label)
 		}
+		if t.isTabCloseable(panel.Name) {
+			closeable = true
+		}
 	}
 
 	var b bytes.Buffer
@@ -284,6 +395,11 @@ func (t *TabbedPanels) updateTabLabels() {
 		}
 
 		b.WriteString(fmt.Sprintf(`["%s"]%s%s[""]`, panel.Name, label, spacer))
+		if t.isTabCloseable(panel.Name) {
+			b.WriteString(fmt.Sprintf(`["%s%s"]%c[""] `, tabCloseRegionPrefix, panel.Name, t.closeRune))
+		} else if closeable && t.switcherVertical {
+			b.WriteString("  ")
+		}
 
 		if i == l-1 && !t.switcherVertical {
 			b.WriteString(t.dividerEnd)
@@ -296,6 +412,9 @@ func (t *TabbedPanels) updateTabLabels() {
 	var reqLines int
 	if t.switcherVertical {
 		reqLines = maxWidth + 2
+		if closeable {
+			reqLines += 2
+		}
 	} else {
 		if t.switcherHeight > 0 {
 			reqLines = t.switcherHeight
@@ -367,6 +486,43 @@ func (t *TabbedPanels) Draw(screen tcell.Screen) {
 	t.Flex.Draw(screen)
 }
 
+// interceptKey switches to the previous or next tab when the corresponding
+// shortcut is pressed while the tabbed panels or their contents have focus.
+// Other key events are passed on to the focused item.
+func (t *TabbedPanels) interceptKey(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	if t.setFocus == nil {
+		t.setFocus = setFocus
+	}
+
+	offset := 0
+	if HitShortcut(event, Keys.PreviousTab) {
+		offset = -1
+	} else if HitShortcut(event, Keys.NextTab) {
+		offset = 1
+	} else {
+		return t.Flex.interceptKey(event, setFocus)
+	}
+
+	t.RLock()
+	var names []string
+	current := -1
+	for _, panel := range t.panels.panels {
+		if panel.Name == t.currentTab {
+			current = len(names)
+		}
+		names = append(names, panel.Name)
+	}
+	t.RUnlock()
+	if len(names) == 0 {
+		return true
+	}
+
+	next := (current + offset + len(names)) % len(names)
+	t.SetCurrentTab(names[next])
+	setFocus(t.panels)
+	return true
+}
+
 // InputHandler returns the handler for this primitive.
 func (t *TabbedPanels) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
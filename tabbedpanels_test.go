@@ -0,0 +1,136 @@
+package cview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTabbedPanelsCloseable(t *testing.T) {
+	t.Parallel()
+
+	tp := NewTabbedPanels()
+	tp.AddTab("one", "One", NewBox())
+	tp.AddTab("two", "Two", NewBox())
+	tp.AddTab("three", "Three", NewBox())
+	tp.SetTabsCloseable(true)
+	tp.SetTabCloseable("three", false)
+
+	var closed []string
+	tp.SetTabClosedFunc(func(name string) {
+		closed = append(closed, name)
+	})
+
+	app, err := newTestApp(tp)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	tp.SetRect(0, 0, 80, 24)
+
+	// clickClose clicks the close rune of the tab with the given label.
+	clickClose := func(label string) {
+		tp.Draw(app.screen)
+		var row []rune
+		for x := 0; x < 80; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			row = append(row, r)
+		}
+		for x := range row {
+			if string(row[x:x+len(label)]) == label {
+				x += len(label) + 1
+				tp.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(x, 0, tcell.ButtonPrimary, tcell.ModNone), func(p Primitive) {})
+				return
+			}
+		}
+		t.Fatalf("failed to find tab %s in %q", label, string(row))
+	}
+
+	tp.SetCurrentTab("two")
+	clickClose("Two")
+	if tp.HasTab("two") {
+		t.Errorf("failed to close tab")
+	} else if len(closed) != 1 || closed[0] != "two" {
+		t.Errorf("failed to call closed handler: expected [two], got %v", closed)
+	} else if tp.GetCurrentTab() != "three" {
+		t.Errorf("failed to select adjacent tab: expected three, got %s", tp.GetCurrentTab())
+	}
+
+	// Pinned tabs may not be closed.
+	clickClose("Three")
+	if !tp.HasTab("three") {
+		t.Errorf("failed to pin tab")
+	}
+}
+
+func TestTabbedPanelsKeyboard(t *testing.T) {
+	t.Parallel()
+
+	inputs := []*InputField{NewInputField(), NewInputField(), NewInputField()}
+	tp := NewTabbedPanels()
+	tp.AddTab("one", "One", inputs[0])
+	tp.AddTab("two", "Two", inputs[1])
+	tp.AddTab("three", "Three", inputs[2])
+
+	changed := make(chan struct{}, 10)
+	tp.SetChangedFunc(func() {
+		changed <- struct{}{}
+	})
+
+	root := NewFlex()
+	root.AddItem(tp, 0, 1, true)
+
+	app, screen, done := runTestApp(root)
+	defer func() {
+		app.Stop()
+		<-done
+	}()
+	app.QueueUpdateSync(func() {
+		app.SetFocus(inputs[0])
+	})
+
+	for _, test := range []struct {
+		key tcell.Key
+		tab int
+	}{
+		{tcell.KeyPgDn, 1},
+		{tcell.KeyPgDn, 2},
+		{tcell.KeyPgDn, 0},
+		{tcell.KeyPgUp, 2},
+	} {
+		screen.InjectKey(test.key, 0, tcell.ModCtrl)
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			t.Fatalf("failed to call changed func")
+		}
+		app.QueueUpdateSync(func() {})
+		for len(changed) > 0 {
+			<-changed
+		}
+
+		if tab := tp.GetCurrentTab(); tab != []string{"one", "two", "three"}[test.tab] {
+			t.Errorf("failed to switch tab: expected %d, got %s", test.tab, tab)
+		} else if app.GetFocus() != inputs[test.tab] {
+			t.Errorf("failed to focus tab %d", test.tab)
+		}
+	}
+
+	// Other keys are passed on to the focused primitive.
+	typed := make(chan string, 1)
+	inputs[2].SetChangedFunc(func(text string) {
+		typed <- text
+	})
+	screen.InjectKey(tcell.KeyRune, 'x', tcell.ModNone)
+	select {
+	case text := <-typed:
+		if text != "x" {
+			t.Errorf("failed to pass on unhandled key: expected x, got %s", text)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("failed to pass on unhandled key")
+	}
+	if tab := tp.GetCurrentTab(); tab != "three" {
+		t.Errorf("failed to pass on unhandled key: switched to tab %s", tab)
+	}
+}
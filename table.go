@@ -2,6 +2,8 @@ package cview
 
 import (
 	"bytes"
+	"encoding/csv"
+	"io"
 	"sort"
 	"sync"
 
@@ -44,6 +46,10 @@ type TableCell struct {
 	// If set to true, this cell cannot be selected.
 	NotSelectable bool
 
+	// The number of columns the cell spans. Values less than 2 mean that the
+	// cell occupies a single column.
+	ColSpan int
+
 	// The position and width of the cell the last time table was drawn.
 	x, y, width int
 
@@ -107,6 +113,16 @@ func (c *TableCell) SetMaxWidth(maxWidth int) {
 	c.MaxWidth = maxWidth
 }
 
+// SetColSpan sets the number of columns the cell spans. The cell's text and
+// background extend across the spanned columns, and any cells in the columns
+// it covers are not drawn or selectable.
+func (c *TableCell) SetColSpan(span int) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.ColSpan = span
+}
+
 // SetExpansion sets the value by which the column of this cell expands if the
 // available width for the table is more than the table width (prior to applying
 // this expansion value). This is a proportional value. The amount of unused
@@ -340,6 +356,9 @@ type Table struct {
 	// Likewise for entire columns.
 	selectionChanged func(row, column int)
 
+	// An optional function which gets called when the user clicks on a cell.
+	cellClicked func(row, column int, cell *TableCell) bool
+
 	// An optional function which gets called when the user presses Escape, Tab,
 	// or Backtab. Also when the user presses Enter if nothing is selectable.
 	done func(key tcell.Key)
@@ -540,6 +559,10 @@ func (t *Table) SetSelectedFunc(handler func(row, column int)) {
 // selection changes. The handler receives the position of the new selection.
 // If entire rows are selected, the column index is undefined. Likewise for
 // entire columns.
+//
+// The handler is called when the selection is moved using the keyboard or
+// the mouse and on every call to Select(), but not when the Enter key is
+// pressed. This may be used to update a preview of the selected row.
 func (t *Table) SetSelectionChangedFunc(handler func(row, column int)) {
 	t.Lock()
 	defer t.Unlock()
@@ -547,6 +570,17 @@ func (t *Table) SetSelectionChangedFunc(handler func(row, column int)) {
 	t.selectionChanged = handler
 }
 
+// SetCellClickedFunc sets a handler which is called when the user clicks on a
+// cell, regardless of whether the table is selectable. The handler receives
+// the position of the clicked cell and the cell itself. Return true to consume
+// the click, preventing any sorting or selection from taking place.
+func (t *Table) SetCellClickedFunc(handler func(row, column int, cell *TableCell) bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.cellClicked = handler
+}
+
 // SetDoneFunc sets a handler which is called whenever the user presses the
 // Escape, Tab, or Backtab key. If nothing is selected, it is also called when
 // user presses the Enter key (because pressing Enter on a selection triggers
@@ -607,6 +641,60 @@ func (t *Table) GetCell(row, column int) *TableCell {
 	return t.cells[row][column]
 }
 
+// LoadCSV replaces the contents of the table with the CSV data read from the
+// provided reader. When hasHeader is true, the cells of the first row are not
+// selectable. Use SetFixed to keep the header visible while scrolling. The
+// table is not modified when an error occurs.
+func (t *Table) LoadCSV(r io.Reader, hasHeader bool) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	cells := make([][]*TableCell, len(records))
+	lastColumn := -1
+	for row, record := range records {
+		cells[row] = make([]*TableCell, len(record))
+		for column, text := range record {
+			cell := NewTableCell(text)
+			if hasHeader && row == 0 {
+				cell.SetSelectable(false)
+			}
+			cells[row][column] = cell
+		}
+		if len(record)-1 > lastColumn {
+			lastColumn = len(record) - 1
+		}
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.cells = cells
+	t.lastColumn = lastColumn
+	return nil
+}
+
+// WriteCSV writes the text of all cells in the table to the provided writer as
+// CSV data.
+func (t *Table) WriteCSV(w io.Writer) error {
+	t.RLock()
+	records := make([][]string, len(t.cells))
+	for row := range t.cells {
+		records[row] = make([]string, len(t.cells[row]))
+		for column, cell := range t.cells[row] {
+			if cell != nil {
+				records[row][column] = cell.GetText()
+			}
+		}
+	}
+	t.RUnlock()
+
+	return csv.NewWriter(w).WriteAll(records)
+}
+
 // RemoveRow removes the row at the given position from the table. If there is
 // no such row, this has no effect.
 func (t *Table) RemoveRow(row int) {
@@ -726,9 +814,53 @@ func (t *Table) cellAt(x, y int) (row, column int) {
 		}
 	}
 
+	// Clicks on columns covered by a spanning cell hit that cell.
+	if row >= 0 && column >= 0 {
+		column = t.spanStart(row, column)
+	}
+
 	return
 }
 
+// spanStarts returns, for each column of the given row, the column at which the
+// cell covering it starts. Nil is returned when no cell in the row spans
+// multiple columns.
+func (t *Table) spanStarts(row int) []int {
+	if row < 0 || row >= len(t.cells) {
+		return nil
+	}
+
+	var starts []int
+	for column, cell := range t.cells[row] {
+		if cell == nil || cell.ColSpan < 2 || (starts != nil && column < len(starts) && starts[column] != column) {
+			continue
+		}
+		if starts == nil {
+			starts = make([]int, len(t.cells[row]))
+			for i := range starts {
+				starts[i] = i
+			}
+		}
+		for covered := column + 1; covered < column+cell.ColSpan; covered++ {
+			if covered < len(starts) {
+				starts[covered] = column
+			} else {
+				starts = append(starts, column)
+			}
+		}
+	}
+	return starts
+}
+
+// spanStart returns the column at which the cell covering the given position
+// starts.
+func (t *Table) spanStart(row, column int) int {
+	if starts := t.spanStarts(row); column >= 0 && column < len(starts) {
+		return starts[column]
+	}
+	return column
+}
+
 // ScrollToBeginning scrolls the table to the beginning to that the top left
 // corner of the table is shown. Note that this position may be corrected if
 // there is a selection.
@@ -773,33 +905,52 @@ func (t *Table) SetSortFunc(sortFunc func(column, i, j int) bool) {
 }
 
 // Sort sorts the table by the column at the given index. You may set a custom
-// sorting function with SetSortFunc.
+// sorting function with SetSortFunc. Fixed rows are not sorted. When a row is
+// selected, the selection follows the row to its new position.
 func (t *Table) Sort(column int, descending bool) {
 	t.Lock()
 	defer t.Unlock()
 
-	if len(t.cells) == 0 || column < 0 || column >= len(t.cells[0]) {
+	if len(t.cells) <= t.fixedRows || column < 0 {
 		return
 	}
 
-	if t.sortFunc == nil {
-		t.sortFunc = func(column, i, j int) bool {
-			return bytes.Compare(t.cells[i][column].Text, t.cells[j][column].Text) == -1
+	sortFunc := t.sortFunc
+	if sortFunc == nil {
+		sortFunc = func(column, i, j int) bool {
+			var a, b []byte
+			if column < len(t.cells[i]) && t.cells[i][column] != nil {
+				a = t.cells[i][column].Text
+			}
+			if column < len(t.cells[j]) && t.cells[j][column] != nil {
+				b = t.cells[j][column].Text
+			}
+			return bytes.Compare(a, b) == -1
 		}
 	}
 
-	sort.SliceStable(t.cells, func(i, j int) bool {
-		if i < t.fixedRows {
-			return i < j
-		} else if j < t.fixedRows {
-			return j > i
-		}
-
+	// Sort the row indices, leaving the cells in place while comparing.
+	order := make([]int, len(t.cells))
+	for i := range order {
+		order[i] = i
+	}
+	rows := order[t.fixedRows:]
+	sort.SliceStable(rows, func(i, j int) bool {
 		if !descending {
-			return t.sortFunc(column, i, j)
+			return sortFunc(column, rows[i], rows[j])
 		}
-		return t.sortFunc(column, j, i)
+		return sortFunc(column, rows[j], rows[i])
 	})
+
+	cells := make([][]*TableCell, len(t.cells))
+	selectedRow := t.selectedRow
+	for i, row := range order {
+		cells[i] = t.cells[row]
+		if row == selectedRow {
+			t.selectedRow = i
+		}
+	}
+	t.cells = cells
 }
 
 // Draw draws this primitive onto the screen.
@@ -834,6 +985,21 @@ func (t *Table) Draw(screen tcell.Screen) {
 		return t.cells[row][column]
 	}
 
+	// Return the column at which the cell covering the specified position
+	// starts. This differs from the column when a cell spans multiple columns.
+	spanStarts := make(map[int][]int)
+	spanStart := func(row, column int) int {
+		starts, ok := spanStarts[row]
+		if !ok {
+			starts = t.spanStarts(row)
+			spanStarts[row] = starts
+		}
+		if column < len(starts) {
+			return starts[column]
+		}
+		return column
+	}
+
 	// If this cell is not selectable, find the next one.
 	if t.rowsSelectable || t.columnsSelectable {
 		if t.selectedColumn < 0 {
@@ -844,7 +1010,7 @@ func (t *Table) Draw(screen tcell.Screen) {
 		}
 		for t.selectedRow < len(t.cells) {
 			cell := getCell(t.selectedRow, t.selectedColumn)
-			if cell == nil || !cell.NotSelectable {
+			if (cell == nil || !cell.NotSelectable) && spanStart(t.selectedRow, t.selectedColumn) == t.selectedColumn {
 				break
 			}
 			t.selectedColumn++
@@ -983,11 +1149,16 @@ ColumnLoop:
 			evaluationRows = allRows
 		}
 		for _, row := range evaluationRows {
-			if cell := getCell(row, column); cell != nil {
+			if cell := getCell(row, spanStart(row, column)); cell != nil {
 				_, _, _, _, _, _, cellWidth := decomposeText(cell.Text, true, false)
 				if cell.MaxWidth > 0 && cell.MaxWidth < cellWidth {
 					cellWidth = cell.MaxWidth
 				}
+				if cell.ColSpan > 1 {
+					// Distribute the text across the spanned columns and the
+					// separators between them.
+					cellWidth /= cell.ColSpan
+				}
 				if cellWidth > maxWidth {
 					maxWidth = cellWidth
 				}
@@ -1025,6 +1196,16 @@ ColumnLoop:
 		tableWidth = width - toDistribute
 	}
 
+	// Return the width of the cell starting at the specified visible column,
+	// including the visible columns it spans.
+	spanWidth := func(columnIndex int, cell *TableCell) int {
+		w := widths[columnIndex]
+		for i := columnIndex + 1; i < len(columns) && columns[i] < columns[columnIndex]+cell.ColSpan && columns[i] == columns[i-1]+1; i++ {
+			w += widths[i] + 1
+		}
+		return w
+	}
+
 	// Helper function which draws border runes.
 	borderStyle := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.bordersColor)
 	drawBorder := func(colX, rowY int, ch rune) {
@@ -1052,21 +1233,35 @@ ColumnLoop:
 					} else {
 						ch = Borders.LeftT
 					}
-				} else if rowY == 0 {
-					ch = Borders.TopT
+				} else {
+					// Omit the vertical border lines of spanned columns.
+					coveredBelow := spanStart(row, column) != column
+					coveredAbove := rowY == 0 || spanStart(rows[rowY/2-1], column) != column
+					if coveredAbove && coveredBelow {
+						ch = Borders.Horizontal
+					} else if coveredAbove {
+						ch = Borders.TopT
+					} else if coveredBelow {
+						ch = Borders.BottomT
+					}
 				}
 				drawBorder(columnX, rowY, ch)
 				rowY++
 				if rowY >= height {
 					break // No space for the text anymore.
 				}
-				drawBorder(columnX, rowY, Borders.Vertical)
-			} else if columnIndex > 0 {
+				if spanStart(row, column) == column {
+					drawBorder(columnX, rowY, Borders.Vertical)
+				}
+			} else if columnIndex > 0 && spanStart(row, column) == column {
 				// Draw separator.
 				drawBorder(columnX, rowY, t.separator)
 			}
 
 			// Get the cell.
+			if spanStart(row, column) != column {
+				continue // Covered by a spanning cell.
+			}
 			cell := getCell(row, column)
 			if cell == nil {
 				continue
@@ -1074,14 +1269,33 @@ ColumnLoop:
 
 			// Draw text.
 			finalWidth := columnWidth
-			if columnX+1+columnWidth >= width {
+			if cell.ColSpan > 1 {
+				finalWidth = spanWidth(columnIndex, cell)
+			}
+			if columnX+1+finalWidth >= width {
 				finalWidth = width - columnX - 1
 			}
 			cell.x, cell.y, cell.width = x+columnX+1, y+rowY, finalWidth
-			_, printed := PrintStyle(screen, cell.Text, x+columnX+1, y+rowY, finalWidth, cell.Align, SetAttributes(tcell.StyleDefault.Foreground(cell.Color), cell.Attributes))
-			if TaggedTextWidth(cell.Text)-printed > 0 && printed > 0 {
-				_, _, style, _ := screen.GetContent(x+columnX+finalWidth, y+rowY)
-				PrintStyle(screen, []byte(string(SemigraphicsHorizontalEllipsis)), x+columnX+finalWidth, y+rowY, 1, AlignLeft, style)
+			printWidth := finalWidth
+			truncated := TaggedTextWidth(cell.Text) > finalWidth
+			if truncated && printWidth > 1 {
+				printWidth-- // Leave room for the ellipsis.
+			}
+			_, printed := PrintStyle(screen, cell.Text, x+columnX+1, y+rowY, printWidth, cell.Align, SetAttributes(tcell.StyleDefault.Foreground(cell.Color), cell.Attributes))
+			if truncated && printed > 0 {
+				// A wide rune may end before the last available cell. Place
+				// the ellipsis right after it and take the style from the
+				// rune's first cell.
+				ellipsisX := x + columnX + 1 + printed
+				if printed == finalWidth {
+					ellipsisX--
+				}
+				styleX := x + columnX + printed
+				if _, _, _, w := screen.GetContent(styleX-1, y+rowY); w > 1 && styleX-1 >= x+columnX+1 {
+					styleX--
+				}
+				_, _, style, _ := screen.GetContent(styleX, y+rowY)
+				PrintStyle(screen, []byte(string(SemigraphicsHorizontalEllipsis)), ellipsisX, y+rowY, 1, AlignLeft, style)
 			}
 		}
 
@@ -1093,6 +1307,8 @@ ColumnLoop:
 			ch := Borders.BottomT
 			if columnIndex == 0 {
 				ch = Borders.BottomLeft
+			} else if len(rows) > 0 && spanStart(rows[len(rows)-1], column) != column {
+				ch = Borders.Horizontal
 			}
 			drawBorder(columnX, rowY, ch)
 		}
@@ -1200,11 +1416,19 @@ ColumnLoop:
 		rowSelected := t.rowsSelectable && !t.columnsSelectable && row == t.selectedRow
 		for columnIndex, column := range columns {
 			columnWidth := widths[columnIndex]
+			cellX := columnX
+			columnX += columnWidth + 1
+			if spanStart(row, column) != column {
+				continue // Covered by a spanning cell.
+			}
 			cell := getCell(row, column)
 			if cell == nil {
 				continue
 			}
-			bx, by, bw, bh := x+columnX, y+rowY, columnWidth+1, 1
+			if cell.ColSpan > 1 {
+				columnWidth = spanWidth(columnIndex, cell)
+			}
+			bx, by, bw, bh := x+cellX, y+rowY, columnWidth+1, 1
 			if t.borders {
 				by = y + rowY*2
 				bw++
@@ -1224,7 +1448,6 @@ ColumnLoop:
 			if !ok {
 				backgroundColors = append(backgroundColors, cell.BackgroundColor)
 			}
-			columnX += columnWidth + 1
 		}
 	}
 	sort.Slice(backgroundColors, func(i int, j int) bool {
@@ -1284,6 +1507,12 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 				if row < t.fixedRows || row >= len(t.cells) || column < t.fixedColumns || column > t.lastColumn {
 					return false
 				}
+				if t.spanStart(row, column) != column {
+					return false // Covered by a spanning cell.
+				}
+				if column >= len(t.cells[row]) {
+					return true
+				}
 				cell := t.cells[row][column]
 				return cell == nil || !cell.NotSelectable
 			}
@@ -1433,6 +1662,17 @@ func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 
 		switch action {
 		case MouseLeftClick:
+			t.RLock()
+			cellClicked := t.cellClicked
+			t.RUnlock()
+			if cellClicked != nil {
+				row, column := t.cellAt(x, y)
+				if row >= 0 && column >= 0 && cellClicked(row, column, t.GetCell(row, column)) {
+					setFocus(t)
+					return true, nil
+				}
+			}
+
 			_, tableY, _, _ := t.GetInnerRect()
 			mul := 1
 			maxY := tableY
@@ -1471,6 +1711,12 @@ func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 		case MouseScrollDown:
 			t.rowOffset++
 			consumed = true
+		case MouseScrollLeft:
+			t.columnOffset--
+			consumed = true
+		case MouseScrollRight:
+			t.columnOffset++
+			consumed = true
 		}
 
 		return
@@ -1,8 +1,12 @@
 package cview
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 var tableTestCases = generateTableTestCases()
@@ -51,6 +55,173 @@ func TestTable(t *testing.T) {
 	}
 }
 
+func TestTableSort(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable()
+	for row, text := range []string{"header", "c", "a", "b"} {
+		table.SetCellSimple(row, 0, text)
+	}
+	table.SetCellSimple(2, 1, "second column")
+	table.SetFixed(1, 0)
+	table.SetSelectable(true, false)
+	table.Select(1, 0)
+
+	expectTableColumn := func(expected ...string) {
+		t.Helper()
+
+		for row, text := range expected {
+			if got := table.GetCell(row, 0).GetText(); got != text {
+				t.Errorf("failed to sort Table: expected %q at row %d, got %q", text, row, got)
+			}
+		}
+	}
+
+	table.Sort(0, false)
+	expectTableColumn("header", "a", "b", "c")
+	if row, _ := table.GetSelection(); row != 3 {
+		t.Errorf("failed to sort Table: expected selection to follow row to 3, got %d", row)
+	}
+
+	table.Sort(0, true)
+	expectTableColumn("header", "c", "b", "a")
+	if row, _ := table.GetSelection(); row != 1 {
+		t.Errorf("failed to sort Table: expected selection to follow row to 1, got %d", row)
+	}
+
+	// Rows without a cell in the sorted column are sorted first.
+	table.Sort(1, false)
+	expectTableColumn("header", "c", "b", "a")
+}
+
+func TestTableCellClickedFunc(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable()
+	for row := 0; row < 20; row++ {
+		for column := 0; column < 3; column++ {
+			table.SetCellSimple(row, column, fmt.Sprintf("%d,%d", column, row))
+		}
+	}
+	table.SetFixed(1, 0)
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	table.SetRect(0, 0, 20, 5)
+	table.Draw(app.screen)
+	table.SetOffset(10, 0)
+	table.Draw(app.screen)
+
+	var clicked []string
+	table.SetCellClickedFunc(func(row, column int, cell *TableCell) bool {
+		clicked = append(clicked, fmt.Sprintf("%d/%d/%s", row, column, cell.GetText()))
+		return true
+	})
+
+	handler := table.MouseHandler()
+	for _, pos := range [][2]int{{0, 0}, {5, 1}, {10, 3}} {
+		handler(MouseLeftClick, tcell.NewEventMouse(pos[0], pos[1], tcell.ButtonNone, tcell.ModNone), func(p Primitive) {})
+	}
+
+	expected := []string{"0/0/0,0", "11/1/1,11", "13/2/2,13"}
+	if fmt.Sprint(clicked) != fmt.Sprint(expected) {
+		t.Errorf("failed to click Table cells: expected %v, got %v", expected, clicked)
+	}
+}
+
+func TestTableCSV(t *testing.T) {
+	t.Parallel()
+
+	const data = "name,notes\nalice,\"likes tea, coffee\"\nbob,\"two\nlines\"\ncarol\n"
+
+	table := NewTable()
+	if err := table.LoadCSV(strings.NewReader(data), true); err != nil {
+		t.Fatalf("failed to load CSV: %s", err)
+	}
+
+	if rows, columns := table.GetRowCount(), table.GetColumnCount(); rows != 4 || columns != 2 {
+		t.Errorf("failed to load CSV: expected 4 rows and 2 columns, got %d and %d", rows, columns)
+	}
+	if text := table.GetCell(1, 1).GetText(); text != "likes tea, coffee" {
+		t.Errorf("failed to load CSV: expected quoted field, got %q", text)
+	}
+	if text := table.GetCell(2, 1).GetText(); text != "two\nlines" {
+		t.Errorf("failed to load CSV: expected multi-line field, got %q", text)
+	}
+	if !table.GetCell(0, 0).NotSelectable || table.GetCell(1, 0).NotSelectable {
+		t.Error("failed to load CSV: expected only the header to be not selectable")
+	}
+
+	var b bytes.Buffer
+	if err := table.WriteCSV(&b); err != nil {
+		t.Fatalf("failed to write CSV: %s", err)
+	}
+	if b.String() != data {
+		t.Errorf("failed to write CSV: expected %q, got %q", data, b.String())
+	}
+
+	if err := table.LoadCSV(strings.NewReader("a,\"b\n"), false); err == nil {
+		t.Error("failed to load CSV: expected error for malformed data")
+	}
+	if rows := table.GetRowCount(); rows != 4 {
+		t.Errorf("failed to load CSV: expected table to be unchanged on error, got %d rows", rows)
+	}
+}
+
+func TestTableColSpan(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable()
+	table.SetSeparator('|')
+	header := NewTableCell("Group header")
+	header.SetColSpan(2)
+	table.SetCell(0, 0, header)
+	table.SetCellSimple(0, 1, "hidden")
+	table.SetCellSimple(0, 2, "c")
+	for column, text := range []string{"a", "b", "c"} {
+		table.SetCellSimple(1, column, text)
+	}
+	table.SetSelectable(true, true)
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	table.SetRect(0, 0, 20, 2)
+	table.Draw(app.screen)
+
+	for _, c := range []struct {
+		y        int
+		expected string
+	}{
+		{0, "Group header |c"},
+		{1, "a     |b     |c"},
+	} {
+		var row []rune
+		for x := 0; x < len(c.expected); x++ {
+			r, _, _, _ := app.screen.GetContent(x, c.y)
+			row = append(row, r)
+		}
+		if string(row) != c.expected {
+			t.Errorf("failed to draw Table column span at row %d: expected %q, got %q", c.y, c.expected, string(row))
+		}
+	}
+
+	// Clicking a covered column hits the spanning cell.
+	if row, column := table.cellAt(8, 0); row != 0 || column != 0 {
+		t.Errorf("failed to resolve spanning cell: expected 0,0, got %d,%d", row, column)
+	}
+
+	// Navigation skips covered columns.
+	table.Select(0, 0)
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone), nil)
+	if _, column := table.GetSelection(); column != 2 {
+		t.Errorf("failed to skip covered column: expected column 2, got %d", column)
+	}
+}
+
 func BenchmarkTableDraw(b *testing.B) {
 	for _, c := range tableTestCases {
 		c := c // Capture
@@ -104,3 +275,112 @@ func tc(c *tableTestCase) *Table {
 
 	return table
 }
+
+func TestTableHorizontalScroll(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable()
+	for column := 0; column < 10; column++ {
+		table.SetCellSimple(0, column, fmt.Sprintf("Column %d", column))
+	}
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	table.SetRect(0, 0, 20, 5)
+
+	for _, test := range []struct {
+		buttons tcell.ButtonMask
+		mod     tcell.ModMask
+		column  int
+	}{
+		{tcell.WheelRight, tcell.ModNone, 1},
+		{tcell.WheelDown, tcell.ModShift, 2},
+		{tcell.WheelLeft, tcell.ModNone, 1},
+		{tcell.WheelUp, tcell.ModShift, 0},
+	} {
+		app.fireMouseActions(tcell.NewEventMouse(1, 1, test.buttons, test.mod))
+		table.Draw(app.screen)
+		if _, column := table.GetOffset(); column != test.column {
+			t.Errorf("failed to scroll Table horizontally: expected column offset %d, got %d", test.column, column)
+		}
+	}
+}
+
+func TestTableWideRunes(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable()
+	table.SetFixed(0, 1)
+	for row := 0; row < 3; row++ {
+		table.SetCellSimple(row, 0, fmt.Sprintf("日本%d", row))
+		for column, text := range []string{"東京都", "大阪", "名古屋市", "札幌", "福岡県庁", "横浜"} {
+			table.SetCellSimple(row, column+1, text)
+		}
+	}
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	for width := 14; width < 24; width++ {
+		for offset := 0; offset < 5; offset++ {
+			app.screen.Clear()
+			table.SetRect(0, 0, width, 3)
+			table.SetOffset(0, offset)
+			table.Draw(app.screen)
+
+			var line []rune
+			for x := 0; x < width; x++ {
+				m, _, _, w := app.screen.GetContent(x, 0)
+				line = append(line, m)
+				if w > 1 {
+					// The second half of a wide rune must not be overwritten.
+					if next, _, _, _ := app.screen.GetContent(x+1, 0); next != ' ' {
+						t.Errorf("failed to draw wide runes at width %d, offset %d: %c overlaps %c", width, offset, next, m)
+					}
+					x++
+				}
+			}
+			if !strings.HasPrefix(string(line), "日本0 ") {
+				t.Errorf("failed to draw fixed column at width %d, offset %d: got %s", width, offset, string(line))
+			}
+		}
+	}
+}
+
+func TestTableSelectionChangedFunc(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable()
+	for row := 0; row < 5; row++ {
+		table.SetCellSimple(row, 0, fmt.Sprintf("%d", row))
+	}
+	table.SetSelectable(true, false)
+
+	var changed []int
+	table.SetSelectionChangedFunc(func(row, column int) {
+		changed = append(changed, row)
+	})
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	table.SetRect(0, 0, 20, 5)
+	table.Draw(app.screen)
+
+	table.Select(1, 0)
+
+	inputHandler := table.InputHandler()
+	inputHandler(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), nil)
+	inputHandler(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	table.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(0, 4, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {})
+
+	expected := []int{1, 2, 4}
+	if fmt.Sprint(changed) != fmt.Sprint(expected) {
+		t.Errorf("failed to notify selection changes: expected %v, got %v", expected, changed)
+	}
+}
@@ -2,7 +2,10 @@ package cview
 
 import (
 	"bytes"
+	"io"
 	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"unicode"
 	"unicode/utf8"
@@ -28,6 +31,7 @@ var (
 type textViewIndex struct {
 	Line            int    // The index into the "buffer" variable.
 	Pos             int    // The index into the "buffer" line ([]byte position).
+	StrippedPos     int    // The index into the "buffer" line with all tags stripped ([]byte position).
 	NextPos         int    // The (byte) index of the next character in this buffer line.
 	Width           int    // The screen width of this line.
 	ForegroundColor string // The starting foreground color ("" = don't change, "-" = reset).
@@ -189,10 +193,42 @@ type TextView struct {
 	// The background color of highlighted text.
 	highlightBackground tcell.Color
 
+	// Whether or not line numbers are shown to the left of the text.
+	lineNumbers bool
+
+	// The color of the line numbers.
+	lineNumbersColor tcell.Color
+
+	// The rune drawn in front of wrapped continuation rows (0 = none).
+	wrapIndicator rune
+
+	// The color of the wrap indicator.
+	wrapIndicatorColor tcell.Color
+
+	// An optional function which returns the background color of a line.
+	lineBackground func(line int) tcell.Color
+
+	// The highlighted line of text. Set to -1 if there is no such line.
+	currentLine int
+
+	// The style of the highlighted line of text.
+	currentLineStyle tcell.Style
+
+	// A temporary flag which, when true, will automatically bring the current
+	// line into the visible screen.
+	scrollToCurrentLine bool
+
 	// If set to true, the text color can be changed dynamically by piping color
 	// strings in square brackets to the text view.
 	dynamicColors bool
 
+	// An optional writer which translates ANSI escape sequences in written text
+	// into color tags, the buffer receiving the translated text, and the bytes
+	// of an incomplete UTF-8 character which was written last.
+	ansiWriter *ansi
+	ansiOutput bytes.Buffer
+	ansiRecent []byte
+
 	// If set to true, region tags can be used to define regions.
 	regions bool
 
@@ -200,10 +236,31 @@ type TextView struct {
 	// highlight(s) into the visible screen.
 	scrollToHighlights bool
 
+	// The buffer line to bring to the top of the text view the next time it
+	// is drawn. Set to -1 if there is no such line.
+	scrollToLine int
+
 	// If true, setting new highlights will be a XOR instead of an overwrite
 	// operation.
 	toggleHighlights bool
 
+	// If true, clicking a region highlights it.
+	highlightOnClick bool
+
+	// The current search term, or nil when not searching.
+	searchTerm []byte
+
+	// The buffer line and the position within the stripped buffer line of the
+	// current search match. Set to -1 if there is no current match.
+	searchLine, searchPos int
+
+	// The style applied to search matches.
+	searchStyle tcell.Style
+
+	// A temporary flag which, when true, will automatically bring the current
+	// search match into the visible screen.
+	scrollToSearch bool
+
 	// An optional function which is called when the content of the text view has
 	// changed.
 	changed func()
@@ -216,8 +273,10 @@ type TextView struct {
 	// highlighted.
 	highlighted func(added, removed, remaining []string)
 
+	// An optional function which is called when a region is clicked.
+	clicked func(regionID string)
+
 	sync.RWMutex
-	clicked func(regionId string)
 }
 
 // NewTextView returns a new text view.
@@ -226,6 +285,9 @@ func NewTextView() *TextView {
 		Box:                 NewBox(),
 		highlights:          make(map[string]struct{}),
 		lineOffset:          -1,
+		scrollToLine:        -1,
+		currentLine:         -1,
+		currentLineStyle:    tcell.StyleDefault.Foreground(Styles.PrimitiveBackgroundColor).Background(Styles.PrimaryTextColor),
 		reindex:             true,
 		scrollable:          true,
 		scrollBarVisibility: ScrollBarAuto,
@@ -236,6 +298,11 @@ func NewTextView() *TextView {
 		textColor:           Styles.PrimaryTextColor,
 		highlightForeground: Styles.PrimitiveBackgroundColor,
 		highlightBackground: Styles.PrimaryTextColor,
+		lineNumbersColor:    Styles.TertiaryTextColor,
+		wrapIndicatorColor:  Styles.TertiaryTextColor,
+		searchLine:          -1,
+		searchPos:           -1,
+		searchStyle:         tcell.StyleDefault.Background(Styles.ContrastBackgroundColor),
 	}
 }
 
@@ -346,6 +413,98 @@ func (t *TextView) SetHighlightBackgroundColor(color tcell.Color) {
 	t.highlightBackground = color
 }
 
+// SetLineNumbers sets the flag that, if true, shows the number of each line in
+// a gutter to the left of the text. The gutter is as wide as the number of the
+// last line. Rows which continue a wrapped line show no number.
+func (t *TextView) SetLineNumbers(show bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.lineNumbers != show {
+		t.index = nil
+	}
+	t.lineNumbers = show
+}
+
+// SetLineNumbersColor sets the color of the line numbers.
+func (t *TextView) SetLineNumbersColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.lineNumbersColor = color
+}
+
+// SetWrapIndicator sets the rune which is drawn in the first column of rows
+// that continue a wrapped line, e.g. '↪'. When set, one column is reserved for
+// the indicator. Set to 0 to disable the indicator. This has no effect when
+// wrapping is disabled.
+func (t *TextView) SetWrapIndicator(indicator rune) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.wrapIndicator != indicator {
+		t.index = nil
+	}
+	t.wrapIndicator = indicator
+}
+
+// SetWrapIndicatorColor sets the color of the wrap indicator.
+func (t *TextView) SetWrapIndicatorColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.wrapIndicatorColor = color
+}
+
+// SetLineBackgroundFunc sets a function which returns the background color of
+// the provided line of text (starting with 0). The color fills the entire
+// width of the text view, including rows which continue a wrapped line, and
+// applies wherever no background color is set via color tags. Return
+// tcell.ColorDefault to keep the default background color. The function is
+// called while the text view is drawn and must not call its methods. Provide
+// nil to remove the function.
+func (t *TextView) SetLineBackgroundFunc(handler func(line int) tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.lineBackground = handler
+}
+
+// SetCurrentLine sets the line of text (starting with 0) which is highlighted
+// across the entire width of the text view, including rows which continue a
+// wrapped line. The line is scrolled into view the next time the text view is
+// drawn. Provide a negative value to remove the highlight (the default).
+func (t *TextView) SetCurrentLine(line int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if line < 0 {
+		line = -1
+	}
+	t.currentLine = line
+	t.scrollToCurrentLine = line >= 0
+	if line >= 0 {
+		t.trackEnd = false
+	}
+}
+
+// GetCurrentLine returns the highlighted line of text, or -1 if there is none.
+func (t *TextView) GetCurrentLine() int {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.currentLine
+}
+
+// SetCurrentLineStyle sets the style of the line set with SetCurrentLine.
+// Color tags take precedence over the style's colors.
+func (t *TextView) SetCurrentLineStyle(style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.currentLineStyle = style
+}
+
 // SetBytes sets the text of this text view to the provided byte slice.
 // Previously contained text will be removed.
 func (t *TextView) SetBytes(text []byte) {
@@ -406,6 +565,29 @@ func (t *TextView) SetDynamicColors(dynamic bool) {
 	t.dynamicColors = dynamic
 }
 
+// SetANSIParsing sets the flag that translates ANSI escape sequences (e.g. of
+// colorized program output) in text written to the text view into color tags.
+// Escape sequences which do not set colors or text attributes are removed.
+// Enabling this also enables dynamic colors (see SetDynamicColors). Escape
+// sequences may span multiple writes.
+func (t *TextView) SetANSIParsing(parsing bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	if !parsing {
+		t.ansiWriter = nil
+		t.ansiRecent = nil
+		return
+	} else if t.ansiWriter != nil {
+		return
+	}
+	t.ansiWriter = ANSIWriter(&t.ansiOutput).(*ansi)
+	if !t.dynamicColors {
+		t.index = nil
+	}
+	t.dynamicColors = true
+}
+
 // SetRegions sets the flag that allows to define regions in the text. See class
 // description for details.
 func (t *TextView) SetRegions(regions bool) {
@@ -459,28 +641,82 @@ func (t *TextView) SetDoneFunc(handler func(key tcell.Key)) {
 // Note that because regions are only determined during drawing, this function
 // can only fire for regions that have existed during the last call to Draw().
 func (t *TextView) SetHighlightedFunc(handler func(added, removed, remaining []string)) {
+	t.Lock()
+	defer t.Unlock()
+
 	t.highlighted = handler
 }
 
-// SetClickedFunc Handler to run when a region is clicked.
-func (t *TextView) SetClickedFunc(handler func(regionId string)) {
+// SetHighlightOnClick sets a flag which determines whether clicking a region
+// highlights it, as if Highlight() was called with the region's ID. When
+// highlights are toggled (see SetToggleHighlights), clicking a highlighted
+// region removes its highlight. The handler set with SetHighlightedFunc is
+// called with the resulting highlights.
+func (t *TextView) SetHighlightOnClick(highlight bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.highlightOnClick = highlight
+}
+
+// SetClickedFunc sets a handler which is called when the user clicks on a
+// region. It receives the ID of the clicked region. Clicks outside of regions
+// do not call the handler.
+//
+// Note that because regions are only determined during drawing, this function
+// can only fire for regions that have existed during the last call to Draw().
+func (t *TextView) SetClickedFunc(handler func(regionID string)) {
+	t.Lock()
+	defer t.Unlock()
+
 	t.clicked = handler
 }
 
 func (t *TextView) clipBuffer() {
-	if t.maxLines <= 0 {
+	if t.maxLines <= 0 || len(t.buffer) <= t.maxLines {
 		return
 	}
 
-	lenbuf := len(t.buffer)
-	if lenbuf > t.maxLines {
-		t.buffer = t.buffer[lenbuf-t.maxLines:]
+	removed := len(t.buffer) - t.maxLines
+	t.buffer = t.buffer[removed:]
+
+	// Keep showing the same lines when not following the end of the text.
+	if !t.trackEnd && t.lineOffset > 0 {
+		removedRows := removed
+		if t.index != nil {
+			removedRows = 0
+			for _, line := range t.index {
+				if line.Line >= removed {
+					break
+				}
+				removedRows++
+			}
+		}
+		t.lineOffset -= removedRows
+		if t.lineOffset < 0 {
+			t.lineOffset = 0
+		}
+	}
+
+	if t.searchLine >= 0 {
+		t.searchLine -= removed
+		if t.searchLine < 0 {
+			t.searchLine, t.searchPos = -1, -1
+		}
 	}
+
+	// The index refers to lines which have been removed.
+	t.index = nil
 }
 
 // SetMaxLines sets the maximum number of newlines the text view will hold
-// before discarding older data from the buffer.
+// before discarding older data from the buffer. When lines are discarded, the
+// scroll position is adjusted to keep showing the same lines. A value of 0
+// means the number of lines is unlimited.
 func (t *TextView) SetMaxLines(maxLines int) {
+	t.Lock()
+	defer t.Unlock()
+
 	t.maxLines = maxLines
 	t.clipBuffer()
 }
@@ -527,7 +763,9 @@ func (t *TextView) ScrollToEnd() {
 }
 
 // GetScrollOffset returns the number of rows and columns that are skipped at
-// the top left corner when the text view has been scrolled.
+// the top left corner when the text view has been scrolled. Rows are screen
+// rows, which differ from lines of text when lines are wrapped. See
+// GetScrollLine for the line of text shown at the top.
 func (t *TextView) GetScrollOffset() (row, column int) {
 	t.RLock()
 	defer t.RUnlock()
@@ -535,6 +773,44 @@ func (t *TextView) GetScrollOffset() (row, column int) {
 	return t.lineOffset, t.columnOffset
 }
 
+// ScrollToLine scrolls to the specified line of text (starting with 0) if the
+// text view is scrollable, taking wrapped lines into account. The line is
+// clamped to the available lines. The scroll position is applied the next
+// time the text view is drawn.
+func (t *TextView) ScrollToLine(line int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if !t.scrollable {
+		return
+	}
+	if line >= len(t.buffer) {
+		line = len(t.buffer) - 1
+	}
+	if line < 0 {
+		line = 0
+	}
+	t.scrollToLine = line
+	t.trackEnd = false
+}
+
+// GetScrollLine returns the line of text (starting with 0) shown at the top
+// of the text view. Unlike the row returned by GetScrollOffset, wrapped lines
+// are counted once.
+func (t *TextView) GetScrollLine() int {
+	t.RLock()
+	defer t.RUnlock()
+
+	if t.scrollToLine >= 0 {
+		return t.scrollToLine
+	} else if t.lineOffset <= 0 || len(t.index) == 0 {
+		return 0
+	} else if t.lineOffset >= len(t.index) {
+		return t.index[len(t.index)-1].Line
+	}
+	return t.index[t.lineOffset].Line
+}
+
 // Clear removes all text from the buffer.
 func (t *TextView) Clear() {
 	t.Lock()
@@ -590,7 +866,9 @@ func (t *TextView) Highlight(regionIDs ...string) {
 	var added, removed, remaining []string
 	if t.highlighted != nil {
 		for _, regionID := range regionIDs {
-			if _, ok := t.highlights[regionID]; ok {
+			if regionID == "" {
+				continue
+			} else if _, ok := t.highlights[regionID]; ok {
 				remaining = append(remaining, regionID)
 				delete(t.highlights, regionID)
 			} else {
@@ -660,6 +938,126 @@ func (t *TextView) ScrollToHighlight() {
 	t.trackEnd = false
 }
 
+// SetSearchStyle sets the style applied to search matches. Only the colors and
+// attributes which are set in the style are applied, any other colors are
+// preserved. See Search.
+func (t *TextView) SetSearchStyle(style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.searchStyle = style
+}
+
+// Search searches the text for the provided term, highlights all matches and
+// scrolls to the next match. Set "forward" to false to scroll to the previous
+// match instead. Searching wraps around at the beginning and end of the text.
+// When the term differs from the previous search, the first match starting at
+// the top visible line is selected. Color and region tags are ignored while
+// searching. The search is case-sensitive.
+//
+// Returns false if the term was not found.
+func (t *TextView) Search(term string, forward bool) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	if term == "" {
+		t.clearSearch()
+		return false
+	}
+
+	newTerm := !bytes.Equal(t.searchTerm, []byte(term))
+	t.searchTerm = []byte(term)
+
+	// Find all matches.
+	var matches [][2]int
+	for line := range t.buffer {
+		for _, match := range t.findMatches(line) {
+			matches = append(matches, [2]int{line, match[0]})
+		}
+	}
+	if len(matches) == 0 {
+		t.searchLine, t.searchPos = -1, -1
+		return false
+	}
+
+	// Determine the position to start searching from.
+	fromLine, fromPos := t.searchLine, t.searchPos
+	if newTerm || fromLine < 0 {
+		fromLine, fromPos = 0, -1
+		if t.lineOffset > 0 && t.lineOffset < len(t.index) {
+			fromLine = t.index[t.lineOffset].Line
+		}
+		if !forward {
+			fromPos = 0
+		}
+	}
+	before := func(match [2]int) bool {
+		return match[0] < fromLine || match[0] == fromLine && match[1] < fromPos
+	}
+	after := func(match [2]int) bool {
+		return match[0] > fromLine || match[0] == fromLine && match[1] > fromPos
+	}
+
+	// Select the next match, wrapping around.
+	match := matches[0]
+	if !forward {
+		match = matches[len(matches)-1]
+	}
+	if forward {
+		for _, m := range matches {
+			if after(m) {
+				match = m
+				break
+			}
+		}
+	} else {
+		for i := len(matches) - 1; i >= 0; i-- {
+			if before(matches[i]) {
+				match = matches[i]
+				break
+			}
+		}
+	}
+
+	t.searchLine, t.searchPos = match[0], match[1]
+	t.scrollToSearch = true
+	t.trackEnd = false
+	return true
+}
+
+// ClearSearch removes all search match highlights.
+func (t *TextView) ClearSearch() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.clearSearch()
+}
+
+func (t *TextView) clearSearch() {
+	t.searchTerm = nil
+	t.searchLine, t.searchPos = -1, -1
+	t.scrollToSearch = false
+}
+
+// findMatches returns the start and end positions of all search matches in the
+// given buffer line with all tags stripped.
+func (t *TextView) findMatches(line int) (matches [][]int) {
+	if len(t.searchTerm) == 0 || line < 0 || line >= len(t.buffer) {
+		return nil
+	}
+
+	_, _, _, _, _, stripped, _ := decomposeText(t.buffer[line], t.dynamicColors, t.regions)
+	var offset int
+	for {
+		i := bytes.Index(stripped[offset:], t.searchTerm)
+		if i < 0 {
+			return matches
+		}
+		matches = append(matches, []int{offset + i, offset + i + len(t.searchTerm)})
+		offset += i + len(t.searchTerm)
+	}
+}
+
 // GetRegionText returns the text of the region with the given ID. If dynamic
 // colors are enabled, color tags are stripped from the text. Newlines are
 // always returned as '\n' runes.
@@ -740,13 +1138,53 @@ func (t *TextView) GetRegionText(regionID string) string {
 	return escapePattern.ReplaceAllString(buffer.String(), `[$1$2]`)
 }
 
+// SelectedText returns the text of the highlighted regions without any
+// color or region tags. The text of multiple regions is returned in the order
+// in which the regions appear, separated by newlines.
+func (t *TextView) SelectedText() string {
+	t.RLock()
+	var regionIDs []string
+	if t.regions && len(t.highlights) > 0 {
+		found := make(map[string]bool)
+		for _, line := range t.buffer {
+			for _, region := range regionPattern.FindAllSubmatch(line, -1) {
+				regionID := string(region[1])
+				if _, ok := t.highlights[regionID]; ok && !found[regionID] {
+					found[regionID] = true
+					regionIDs = append(regionIDs, regionID)
+				}
+			}
+		}
+	}
+	t.RUnlock()
+
+	texts := make([]string, len(regionIDs))
+	for i, regionID := range regionIDs {
+		texts[i] = t.GetRegionText(regionID)
+	}
+	return strings.Join(texts, "\n")
+}
+
+// WriteTo writes the text of this text view to the provided writer without
+// any color or region tags. This implements the io.WriterTo interface.
+func (t *TextView) WriteTo(w io.Writer) (n int64, err error) {
+	written, err := w.Write(t.GetBytes(true))
+	return int64(written), err
+}
+
 // Focus is called when this primitive receives focus.
 func (t *TextView) Focus(delegate func(p Primitive)) {
 	t.Lock()
-	defer t.Unlock()
 
 	// Implemented here with locking because this is used by layout primitives.
+	hadFocus := t.hasFocus
 	t.hasFocus = true
+
+	t.Unlock()
+
+	if !hadFocus {
+		t.focusChanged(true)
+	}
 }
 
 // HasFocus returns whether or not this primitive has focus.
@@ -775,6 +1213,32 @@ func (t *TextView) Write(p []byte) (n int, err error) {
 }
 
 func (t *TextView) write(p []byte) (n int, err error) {
+	// Translate ANSI escape sequences.
+	if t.ansiWriter != nil {
+		length := len(p)
+		p = append(t.ansiRecent, p...)
+		t.ansiRecent = nil
+
+		// Wait for the rest of a trailing incomplete character.
+		start := len(p) - 1
+		for start > 0 && start > len(p)-utf8.UTFMax && !utf8.RuneStart(p[start]) {
+			start--
+		}
+		if start >= 0 && !utf8.FullRune(p[start:]) {
+			t.ansiRecent = p[start:]
+			p = p[:start]
+		}
+
+		t.ansiOutput.Reset()
+		if _, err := t.ansiWriter.Write(p); err != nil {
+			return 0, err
+		}
+		p = append([]byte(nil), t.ansiOutput.Bytes()...)
+		defer func() {
+			n = length
+		}()
+	}
+
 	// Copy data over.
 	newBytes := append(t.recentBytes, p...)
 	t.recentBytes = nil
@@ -918,11 +1382,12 @@ func (t *TextView) reindexBuffer(width int) {
 		}
 
 		// Create index from split lines.
-		var originalPos, colorPos, regionPos, escapePos int
+		var originalPos, strippedPos, colorPos, regionPos, escapePos int
 		for _, splitLine := range splitLines {
 			line := &textViewIndex{
 				Line:            bufferIndex,
 				Pos:             originalPos,
+				StrippedPos:     strippedPos,
 				ForegroundColor: foregroundColor,
 				BackgroundColor: backgroundColor,
 				Attributes:      attributes,
@@ -1001,6 +1466,7 @@ func (t *TextView) reindexBuffer(width int) {
 
 			// Advance to next line.
 			originalPos += lineLength + totalTagLength
+			strippedPos += lineLength
 
 			// Append this line.
 			line.NextPos = originalPos
@@ -1049,6 +1515,25 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	}
 	t.pageSize = height
 
+	// Reserve space for line numbers.
+	var gutterWidth int
+	if t.lineNumbers {
+		gutterWidth = len(strconv.Itoa(len(t.buffer))) + 1
+		if gutterWidth > width {
+			gutterWidth = width
+		}
+		x += gutterWidth
+		width -= gutterWidth
+	}
+
+	// Reserve space for the wrap indicator.
+	var indicatorWidth int
+	if t.wrap && t.wrapIndicator != 0 && width > 1 {
+		indicatorWidth = 1
+		x++
+		width--
+	}
+
 	if t.index == nil || width != t.lastWidth || height != t.lastHeight {
 		t.reindexBuffer(width)
 	}
@@ -1112,6 +1597,69 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	}
 	t.scrollToHighlights = false
 
+	// Move to the current search match.
+	if t.scrollToSearch && t.searchLine >= 0 {
+		for i, line := range t.index {
+			if line.Line != t.searchLine || i+1 < len(t.index) && t.index[i+1].Line == t.searchLine && t.index[i+1].StrippedPos <= t.searchPos {
+				continue
+			}
+
+			if i < t.lineOffset || i >= t.lineOffset+height {
+				t.lineOffset = i - height/2
+			}
+
+			if !t.wrap && t.align == AlignLeft {
+				_, _, _, _, _, stripped, _ := decomposeText(t.buffer[line.Line], t.dynamicColors, t.regions)
+				pos := runewidth.StringWidth(string(stripped[:t.searchPos]))
+				if pos-t.columnOffset > 3*width/4 {
+					t.columnOffset = pos - width/2
+				}
+				if pos-t.columnOffset < 0 {
+					t.columnOffset = pos - width/4
+				}
+			}
+			break
+		}
+	}
+	t.scrollToSearch = false
+
+	// Move to the requested line.
+	if t.scrollToLine >= 0 {
+		for i, line := range t.index {
+			if line.Line >= t.scrollToLine {
+				t.lineOffset = i
+				break
+			}
+		}
+	}
+	t.scrollToLine = -1
+
+	// Bring the current line into view.
+	if t.scrollToCurrentLine {
+		first, last := -1, -1
+		for i, line := range t.index {
+			if line.Line == t.currentLine {
+				if first < 0 {
+					first = i
+				}
+				last = i
+			} else if line.Line > t.currentLine {
+				break
+			}
+		}
+		if first >= 0 {
+			if first < t.lineOffset {
+				t.lineOffset = first
+			} else if last >= t.lineOffset+height {
+				t.lineOffset = last - height + 1
+				if t.lineOffset > first {
+					t.lineOffset = first
+				}
+			}
+		}
+	}
+	t.scrollToCurrentLine = false
+
 	// Adjust line offset.
 	if t.lineOffset+height > len(t.index) {
 		t.trackEnd = true
@@ -1164,6 +1712,9 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 	// Draw the buffer.
 	defaultStyle := tcell.StyleDefault.Foreground(t.textColor).Background(t.backgroundColor)
+	searchMatchLine := -1
+	var searchMatches [][]int
+	backgroundLine, lineBackground := -1, tcell.ColorDefault
 	for line := t.lineOffset; line < len(t.index); line++ {
 		// Are we done?
 		if line-t.lineOffset >= height {
@@ -1195,6 +1746,12 @@ func (t *TextView) Draw(screen tcell.Screen) {
 			}
 		}
 
+		// Find search matches.
+		if len(t.searchTerm) > 0 && index.Line != searchMatchLine {
+			searchMatchLine = index.Line
+			searchMatches = t.findMatches(index.Line)
+		}
+
 		// Process tags.
 		colorTagIndices, colorTags, regionIndices, regions, escapeIndices, strippedText, _ := decomposeText(text, t.dynamicColors, t.regions)
 
@@ -1214,6 +1771,33 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 		drawAtY := y + line - t.lineOffset + verticalOffset
 
+		// Print the line number.
+		if indicatorWidth > 0 && line > 0 && t.index[line-1].Line == index.Line {
+			screen.SetContent(x-1, drawAtY, t.wrapIndicator, nil, tcell.StyleDefault.Foreground(t.wrapIndicatorColor).Background(t.backgroundColor))
+		}
+		if gutterWidth > 1 && (line == 0 || t.index[line-1].Line != index.Line) {
+			Print(screen, []byte(strconv.Itoa(index.Line+1)), x-gutterWidth-indicatorWidth, drawAtY, gutterWidth-1, AlignRight, t.lineNumbersColor)
+		}
+
+		// Fill the line background.
+		lineStyle := defaultStyle
+		if index.Line == t.currentLine && drawAtY >= 0 {
+			lineStyle = t.currentLineStyle
+			for column := 0; column < width; column++ {
+				screen.SetContent(x+column, drawAtY, ' ', nil, lineStyle)
+			}
+		} else if t.lineBackground != nil && drawAtY >= 0 {
+			if index.Line != backgroundLine {
+				backgroundLine, lineBackground = index.Line, t.lineBackground(index.Line)
+			}
+			if lineBackground != tcell.ColorDefault {
+				lineStyle = defaultStyle.Background(lineBackground)
+				for column := 0; column < width; column++ {
+					screen.SetContent(x+column, drawAtY, ' ', nil, lineStyle)
+				}
+			}
+		}
+
 		// Print the line.
 		if drawAtY >= 0 {
 			var colorPos, regionPos, escapePos, tagOffset, skipped int
@@ -1259,7 +1843,7 @@ func (t *TextView) Draw(screen tcell.Screen) {
 				// Mix the existing style with the new style.
 				_, _, existingStyle, _ := screen.GetContent(x+posX, drawAtY)
 				_, background, _ := existingStyle.Decompose()
-				style := overlayStyle(background, defaultStyle, foregroundColor, backgroundColor, attributes)
+				style := overlayStyle(background, lineStyle, foregroundColor, backgroundColor, attributes)
 
 				// Do we highlight this character?
 				var highlighted bool
@@ -1290,6 +1874,14 @@ func (t *TextView) Draw(screen tcell.Screen) {
 					style = style.Foreground(fg).Background(bg)
 				}
 
+				// Do we highlight a search match?
+				for _, match := range searchMatches {
+					if pos := index.StrippedPos + textPos; pos >= match[0] && pos < match[1] {
+						style = composeStyle(style, t.searchStyle)
+						break
+					}
+				}
+
 				// Skip to the right.
 				if !t.wrap && skipped < skip {
 					skipped += screenWidth
@@ -1384,8 +1976,12 @@ func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 
 		switch action {
 		case MouseLeftClick:
+			t.RLock()
+			clicked := t.clicked
+			highlightOnClick := t.highlightOnClick
+			var regionID string
 			if t.regions {
-				// Find a region to highlight.
+				// Find the clicked region.
 				for _, region := range t.regionInfos {
 					if y == region.FromY && x < region.FromX ||
 						y == region.ToY && x >= region.ToX ||
@@ -1393,12 +1989,18 @@ func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 						region.ToY >= 0 && y > region.ToY {
 						continue
 					}
-					if t.clicked != nil {
-						t.clicked(string(region.ID))
-					}
+					regionID = string(region.ID)
 					break
 				}
 			}
+			t.RUnlock()
+
+			if highlightOnClick && regionID != "" {
+				t.Highlight(regionID)
+			}
+			if clicked != nil && regionID != "" {
+				clicked(regionID)
+			}
 			consumed = true
 			setFocus(t)
 		case MouseScrollUp:
@@ -1412,6 +2014,16 @@ func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 				t.lineOffset++
 				consumed = true
 			}
+		case MouseScrollLeft:
+			if t.scrollable && !t.wrap {
+				t.columnOffset--
+				consumed = true
+			}
+		case MouseScrollRight:
+			if t.scrollable && !t.wrap {
+				t.columnOffset++
+				consumed = true
+			}
 		}
 
 		return
@@ -3,7 +3,10 @@ package cview
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -353,6 +356,265 @@ func TestTextViewMaxLines(t *testing.T) {
 	}
 }
 
+func TestTextViewSearch(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetDynamicColors(true)
+	for i := 0; i < 100; i++ {
+		if i%40 == 5 {
+			fmt.Fprintf(tv, "Line %d [red]needle[-] here\n", i)
+		} else {
+			fmt.Fprintf(tv, "Line %d\n", i)
+		}
+	}
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	tv.SetRect(0, 0, 40, 10)
+	tv.Draw(app.screen)
+
+	if tv.Search("missing", true) {
+		t.Errorf("failed to search TextView: expected no match")
+	}
+
+	// Find matches in order and wrap around
+
+	for _, expected := range []int{5, 45, 85, 5} {
+		if !tv.Search("needle", true) {
+			t.Fatalf("failed to search TextView: expected match")
+		}
+		tv.Draw(app.screen)
+
+		row, _ := tv.GetScrollOffset()
+		if expected < row || expected >= row+10 {
+			t.Errorf("failed to search TextView: expected line %d to be visible, got offset %d", expected, row)
+		}
+
+		// The match is highlighted, the surrounding text is not.
+		_, _, style, _ := app.screen.GetContent(8, expected-row)
+		fg, bg, _ := style.Decompose()
+		if bg != Styles.ContrastBackgroundColor || fg != tcell.ColorRed {
+			t.Errorf("failed to search TextView: expected highlighted match at line %d, got fg %v bg %v", expected, fg, bg)
+		}
+		_, _, style, _ = app.screen.GetContent(0, expected-row)
+		if _, bg, _ = style.Decompose(); bg == Styles.ContrastBackgroundColor {
+			t.Errorf("failed to search TextView: unexpected highlight at line %d", expected)
+		}
+	}
+
+	// Search backwards
+
+	tv.Search("needle", false)
+	tv.Draw(app.screen)
+	if row, _ := tv.GetScrollOffset(); 85 < row || 85 >= row+10 {
+		t.Errorf("failed to search TextView: expected line 85 to be visible, got offset %d", row)
+	}
+
+	tv.ClearSearch()
+	tv.Draw(app.screen)
+	row, _ := tv.GetScrollOffset()
+	_, _, style, _ := app.screen.GetContent(8, 85-row)
+	if _, bg, _ := style.Decompose(); bg == Styles.ContrastBackgroundColor {
+		t.Errorf("failed to clear TextView search: unexpected highlight")
+	}
+}
+
+func TestTextViewLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetLineNumbers(true)
+	for i := 0; i < 12; i++ {
+		fmt.Fprintf(tv, "L%d\n", i)
+	}
+	fmt.Fprint(tv, "0123456789abcdefghij")
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	tv.SetScrollBarVisibility(ScrollBarNever)
+	tv.SetRect(0, 0, 13, 20)
+	tv.Draw(app.screen)
+
+	for _, c := range []struct {
+		y        int
+		expected string
+	}{
+		{0, " 1 L0"},
+		{9, "10 L9"},
+		{12, "13 0123456789"},
+		{13, "   abcdefghij"},
+	} {
+		var row []rune
+		for x := 0; x < len(c.expected); x++ {
+			r, _, _, _ := app.screen.GetContent(x, c.y)
+			row = append(row, r)
+		}
+		if string(row) != c.expected {
+			t.Errorf("failed to draw TextView line numbers at row %d: expected %q, got %q", c.y, c.expected, string(row))
+		}
+	}
+}
+
+func TestTextViewWrapIndicator(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetWrapIndicator('>')
+	fmt.Fprint(tv, "0123456789abcdefghijABCDEFGHIJ\nshort")
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	tv.SetScrollBarVisibility(ScrollBarNever)
+	tv.SetRect(0, 0, 11, 10)
+	tv.Draw(app.screen)
+
+	for _, c := range []struct {
+		y        int
+		expected string
+	}{
+		{0, " 0123456789"},
+		{1, ">abcdefghij"},
+		{2, ">ABCDEFGHIJ"},
+		{3, " short"},
+	} {
+		var row []rune
+		for x := 0; x < len(c.expected); x++ {
+			r, _, _, _ := app.screen.GetContent(x, c.y)
+			row = append(row, r)
+		}
+		if string(row) != c.expected {
+			t.Errorf("failed to draw TextView wrap indicator at row %d: expected %q, got %q", c.y, c.expected, string(row))
+		}
+	}
+}
+
+func TestTextViewMaxLinesScroll(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetMaxLines(50)
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(tv, "L%d\n", i)
+	}
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	tv.SetRect(0, 0, 20, 10)
+
+	// Scrolled position is kept when lines are discarded
+
+	topLine := func() string {
+		var line []rune
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			line = append(line, r)
+		}
+		return strings.TrimRight(string(line), " ")
+	}
+
+	// The trailing newline already discarded L0, so row 20 shows L21.
+	tv.ScrollTo(20, 0)
+	tv.Draw(app.screen)
+	if line := topLine(); line != "L21" {
+		t.Errorf("failed to scroll TextView: expected L21 at top, got %s", line)
+	}
+	fmt.Fprint(tv, "L50\nL51\nL52\n")
+	tv.Draw(app.screen)
+	if line := topLine(); line != "L21" {
+		t.Errorf("failed to keep TextView scroll position: expected L21 at top, got %s", line)
+	}
+	if row, _ := tv.GetScrollOffset(); row != 17 {
+		t.Errorf("failed to keep TextView scroll position: expected offset 17, got %d", row)
+	}
+
+	// Newest content is shown when following the end of the text
+
+	tv.ScrollToEnd()
+	tv.Draw(app.screen)
+	fmt.Fprint(tv, "L53\nL54")
+	tv.Draw(app.screen)
+	expected := []rune("L54")
+	for x, e := range expected {
+		if r, _, _, _ := app.screen.GetContent(x, 9); r != e {
+			t.Errorf("failed to follow TextView end: expected %c at %d, got %c", e, x, r)
+		}
+	}
+}
+
+func TestTextViewClickedFunc(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetRegions(true)
+	fmt.Fprint(tv, `Go to ["a"]first[""] or ["b"]second[""].`)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	tv.SetRect(0, 0, 40, 5)
+	tv.Draw(app.screen)
+
+	var clicked []string
+	tv.SetClickedFunc(func(regionID string) {
+		clicked = append(clicked, regionID)
+	})
+
+	handler := tv.MouseHandler()
+	for _, x := range []int{0, 7, 16, 30} {
+		handler(MouseLeftClick, tcell.NewEventMouse(x, 0, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {})
+	}
+	if len(clicked) != 2 || clicked[0] != "a" || clicked[1] != "b" {
+		t.Errorf("failed to click TextView regions: expected [a b], got %v", clicked)
+	}
+}
+
+func TestTextViewHighlightOnClick(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetRegions(true)
+	tv.SetToggleHighlights(true)
+	tv.SetHighlightOnClick(true)
+	fmt.Fprint(tv, `Go to ["a"]first[""] or ["b"]second[""].`)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	tv.SetRect(0, 0, 40, 5)
+	tv.Draw(app.screen)
+
+	var calls [][]string
+	tv.SetHighlightedFunc(func(added, removed, remaining []string) {
+		calls = append(calls, []string{strings.Join(added, ","), strings.Join(removed, ","), strings.Join(remaining, ",")})
+		if highlights := tv.GetHighlights(); len(highlights) != len(added)+len(remaining) {
+			t.Errorf("failed to update TextView highlights before notifying: got %v", highlights)
+		}
+	})
+
+	handler := tv.MouseHandler()
+	for _, x := range []int{7, 16, 30, 7} {
+		handler(MouseLeftClick, tcell.NewEventMouse(x, 0, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {})
+	}
+	expected := [][]string{{"a", "", ""}, {"b", "", "a"}, {"", "a", "b"}}
+	if fmt.Sprint(calls) != fmt.Sprint(expected) {
+		t.Errorf("failed to highlight clicked TextView regions: expected %v, got %v", expected, calls)
+	}
+	if highlights := tv.GetHighlights(); len(highlights) != 1 || highlights[0] != "b" {
+		t.Errorf("failed to highlight clicked TextView regions: expected [b], got %v", highlights)
+	}
+}
+
 func generateTestCases() []*textViewTestCase {
 	var cases []*textViewTestCase
 	for i := 0; i < 2; i++ {
@@ -436,3 +698,215 @@ func prepareAppendTextView(t *TextView) ([]byte, error) {
 
 	return b, nil
 }
+
+func TestTextViewSelectedText(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetDynamicColors(true)
+	tv.SetRegions(true)
+	tv.SetWrap(true)
+	fmt.Fprint(tv, "Some [red]colored[-] text with [\"a\"]a highlighted [blue]region[-] which wraps[\"\"]\nand [\"b\"]another[\"\"] one")
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	tv.SetRect(0, 0, 20, 10)
+	tv.Draw(app.screen)
+
+	if text := tv.SelectedText(); text != "" {
+		t.Errorf("failed to get selected text: expected no text, got %q", text)
+	}
+
+	tv.Highlight("b", "a")
+	expected := "a highlighted region which wraps\nanother"
+	if text := tv.SelectedText(); text != expected {
+		t.Errorf("failed to get selected text: expected %q, got %q", expected, text)
+	}
+
+	var b bytes.Buffer
+	if _, err := tv.WriteTo(&b); err != nil {
+		t.Fatalf("failed to write TextView: %s", err)
+	}
+	expected = "Some colored text with a highlighted region which wraps\nand another one"
+	if b.String() != expected {
+		t.Errorf("failed to write TextView: expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestTextViewScrollToLine(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetScrollBarVisibility(ScrollBarNever)
+	for i := 0; i < 10; i++ {
+		if i == 2 {
+			fmt.Fprintln(tv, "2 this line is wrapped")
+			continue
+		}
+		fmt.Fprintln(tv, i)
+	}
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	tv.SetRect(0, 0, 10, 3)
+
+	tv.ScrollToLine(4)
+	tv.Draw(app.screen)
+	if line := tv.GetScrollLine(); line != 4 {
+		t.Errorf("failed to scroll to line: expected line 4, got %d", line)
+	} else if row, _ := tv.GetScrollOffset(); row != 6 {
+		t.Errorf("failed to scroll to line: expected row 6, got %d", row)
+	} else if r, _, _, _ := app.screen.GetContent(0, 0); r != '4' {
+		t.Errorf("failed to scroll to line: expected 4 at the top, got %c", r)
+	}
+
+	tv.ScrollToLine(-5)
+	tv.Draw(app.screen)
+	if line := tv.GetScrollLine(); line != 0 {
+		t.Errorf("failed to clamp line: expected line 0, got %d", line)
+	}
+
+	// Lines beyond the end stop at the last page.
+	tv.ScrollToLine(100)
+	tv.Draw(app.screen)
+	if line := tv.GetScrollLine(); line != 8 {
+		t.Errorf("failed to clamp line: expected line 8, got %d", line)
+	}
+}
+
+func TestTextViewLineBackground(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetScrollBarVisibility(ScrollBarNever)
+	tv.SetDynamicColors(true)
+	tv.SetText("zero\n+one wraps here\n-[:blue]two[:-] x")
+	tv.SetLineBackgroundFunc(func(line int) tcell.Color {
+		switch line {
+		case 1:
+			return tcell.ColorGreen
+		case 2:
+			return tcell.ColorRed
+		}
+		return tcell.ColorDefault
+	})
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	tv.SetRect(0, 0, 10, 3)
+	tv.ScrollTo(1, 0)
+	tv.Draw(app.screen)
+
+	expected := []struct {
+		x, y int
+		bg   tcell.Color
+	}{
+		{9, 0, tcell.ColorGreen},
+		{9, 1, tcell.ColorGreen},
+		{0, 2, tcell.ColorRed},
+		{1, 2, tcell.ColorBlue},
+		{4, 2, tcell.ColorRed},
+		{9, 2, tcell.ColorRed},
+	}
+	for _, e := range expected {
+		_, _, style, _ := app.screen.GetContent(e.x, e.y)
+		if _, bg, _ := style.Decompose(); bg != e.bg {
+			t.Errorf("failed to draw line background at %d,%d: expected %v, got %v", e.x, e.y, e.bg, bg)
+		}
+	}
+}
+
+func TestTextViewANSIParsing(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetANSIParsing(true)
+
+	fmt.Fprint(tv, "\x1b[31mred\x1b[0m \x1b[1mbo")
+	fmt.Fprint(tv, "ld\x1b[0m \x1b[?25lhidden\x1b[")
+	fmt.Fprint(tv, "32m\xc3")
+	fmt.Fprint(tv, "\xa4")
+
+	if text := tv.GetText(true); text != "red bold hiddenä" {
+		t.Errorf("failed to parse ANSI escape sequences: expected %q, got %q", "red bold hiddenä", text)
+	}
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	tv.SetRect(0, 0, 20, 1)
+	tv.Draw(app.screen)
+
+	expected := []struct {
+		x     int
+		r     rune
+		fg    tcell.Color
+		attrs tcell.AttrMask
+	}{
+		{0, 'r', tcell.ColorMaroon, 0},
+		{4, 'b', tv.textColor, tcell.AttrBold},
+		{15, 'ä', tcell.ColorGreen, 0},
+	}
+	for _, e := range expected {
+		r, _, style, _ := app.screen.GetContent(e.x, 0)
+		fg, _, attrs := style.Decompose()
+		if r != e.r || fg != e.fg || attrs != e.attrs {
+			t.Errorf("failed to draw ANSI text at %d: expected %c %v %v, got %c %v %v", e.x, e.r, e.fg, e.attrs, r, fg, attrs)
+		}
+	}
+}
+
+func TestTextViewCurrentLine(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetScrollBarVisibility(ScrollBarNever)
+	tv.SetText("0\n1\n2\n3\n4\n5\n6\n7\n8\n9")
+	tv.SetCurrentLineStyle(tcell.StyleDefault.Background(tcell.ColorGreen))
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	tv.SetRect(0, 0, 10, 3)
+
+	background := func(x, y int) tcell.Color {
+		_, _, style, _ := app.screen.GetContent(x, y)
+		_, bg, _ := style.Decompose()
+		return bg
+	}
+
+	tests := []struct {
+		line, scrollLine, row int
+	}{
+		{7, 5, 2},
+		{6, 5, 1},
+		{1, 1, 0},
+	}
+	for _, test := range tests {
+		tv.SetCurrentLine(test.line)
+		tv.Draw(app.screen)
+
+		if line := tv.GetScrollLine(); line != test.scrollLine {
+			t.Errorf("failed to scroll to current line %d: expected %d, got %d", test.line, test.scrollLine, line)
+		}
+		for y := 0; y < 3; y++ {
+			if current := y == test.row; current != (background(9, y) == tcell.ColorGreen) {
+				t.Errorf("failed to highlight current line %d: unexpected background in row %d", test.line, y)
+			}
+		}
+	}
+
+	tv.SetCurrentLine(-1)
+	tv.Draw(app.screen)
+	if tv.GetCurrentLine() != -1 || background(9, 0) == tcell.ColorGreen {
+		t.Errorf("failed to remove current line")
+	}
+}
@@ -1,6 +1,7 @@
 package cview
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"sync"
@@ -21,6 +22,17 @@ const (
 	treeScrollDown // Move without changing the selection, even when off screen.
 )
 
+// Check states of checkable tree nodes.
+const (
+	treeUnchecked int = iota
+	treeChecked
+	treeIndeterminate
+)
+
+// TreeNodeLoadingText is the text of the placeholder node shown while the
+// children of a node are loaded by its child loader.
+var TreeNodeLoadingText = "Loading..."
+
 // TreeNode represents one node in a tree view.
 type TreeNode struct {
 	// The reference object.
@@ -46,6 +58,13 @@ type TreeNode struct {
 	// Whether or not this node's children should be displayed.
 	expanded bool
 
+	// Whether or not this node shows a checkbox.
+	checkable bool
+
+	// Whether or not this node is checked. The check state of nodes with
+	// checkable children is determined by their children.
+	checked bool
+
 	// The additional horizontal indent of this node's text.
 	indent int
 
@@ -55,6 +74,25 @@ type TreeNode struct {
 	// An optional function which is called when the user selects this node.
 	selected func()
 
+	// An optional function which returns this node's child nodes. It is
+	// called when the node is expanded and its children have not been loaded.
+	childLoader func(node *TreeNode) []*TreeNode
+
+	// An optional function which is called when the child loader has returned.
+	childrenLoaded func()
+
+	// Whether the child loader has returned.
+	loaded bool
+
+	// Children returned by the child loader which have not been attached yet.
+	// They are attached on the main goroutine.
+	loadedChildren []*TreeNode
+	childrenPending bool
+
+	// Incremented each time children are loaded. Children belonging to an
+	// older request are discarded.
+	loadRequest uint64
+
 	// Temporary member variables.
 	parent    *TreeNode // The parent node (nil for the root).
 	level     int       // The hierarchy level (0 for the root, 1 for its children, and so on).
@@ -145,6 +183,34 @@ func (n *TreeNode) GetReference() interface{} {
 	return n.reference
 }
 
+// GetPath returns the path from the root to this node, including the root and
+// the node itself. Each node is identified by its reference if it is a string
+// or implements fmt.Stringer, and by its text otherwise. Paths may be passed to
+// TreeView.SetCurrentByPath to restore the selection after rebuilding a tree.
+//
+// Parent nodes are determined when the tree is drawn or walked.
+func (n *TreeNode) GetPath() []string {
+	var path []string
+	for node := n; node != nil; node = node.GetParent() {
+		node.RLock()
+		path = append([]string{node.pathElement()}, path...)
+		node.RUnlock()
+	}
+	return path
+}
+
+// pathElement returns the string identifying this node in a path. The caller
+// must hold the lock.
+func (n *TreeNode) pathElement() string {
+	switch reference := n.reference.(type) {
+	case string:
+		return reference
+	case fmt.Stringer:
+		return reference.String()
+	}
+	return n.text
+}
+
 // SetChildren sets this node's child nodes.
 func (n *TreeNode) SetChildren(childNodes []*TreeNode) {
 	n.Lock()
@@ -173,9 +239,10 @@ func (n *TreeNode) GetText() string {
 
 // GetChildren returns this node's children.
 func (n *TreeNode) GetChildren() []*TreeNode {
-	n.RLock()
-	defer n.RUnlock()
+	n.Lock()
+	defer n.Unlock()
 
+	n.attachLoadedChildren()
 	return n.children
 }
 
@@ -204,6 +271,80 @@ func (n *TreeNode) SetSelectable(selectable bool) {
 	n.selectable = selectable
 }
 
+// SetCheckable sets a flag indicating whether this node shows a checkbox which
+// may be toggled by the user by pressing Space when the node is focused.
+func (n *TreeNode) SetCheckable(checkable bool) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.checkable = checkable
+}
+
+// IsCheckable returns whether this node shows a checkbox.
+func (n *TreeNode) IsCheckable() bool {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.checkable
+}
+
+// SetChecked sets whether this node and all of its checkable descendants are
+// checked.
+func (n *TreeNode) SetChecked(checked bool) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.setChecked(checked)
+}
+
+func (n *TreeNode) setChecked(checked bool) {
+	n.checked = checked
+	for _, child := range n.children {
+		if child.checkable {
+			child.setChecked(checked)
+		}
+	}
+}
+
+// IsChecked returns whether this node is checked. A node with checkable
+// children is checked when all of them are checked.
+func (n *TreeNode) IsChecked() bool {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.checkState() == treeChecked
+}
+
+// checkState returns the check state of this node. A node with checkable
+// children is indeterminate when only some of them are checked.
+func (n *TreeNode) checkState() int {
+	var checked, unchecked bool
+	for _, child := range n.children {
+		if !child.checkable {
+			continue
+		}
+		switch child.checkState() {
+		case treeChecked:
+			checked = true
+		case treeUnchecked:
+			unchecked = true
+		default:
+			return treeIndeterminate
+		}
+	}
+	switch {
+	case checked && unchecked:
+		return treeIndeterminate
+	case checked:
+		return treeChecked
+	case unchecked:
+		return treeUnchecked
+	case n.checked:
+		return treeChecked
+	}
+	return treeUnchecked
+}
+
 // RemoveChild removes a child node from this node. If the child node cannot be
 // found, nothing happens.
 func (n *TreeNode) RemoveChild(node *TreeNode) bool {
@@ -236,12 +377,109 @@ func (n *TreeNode) SetSelectedFunc(handler func()) {
 	n.selected = handler
 }
 
+// SetChildLoaderFunc sets a function which returns this node's child nodes.
+// It is called in a separate goroutine the first time the node is expanded.
+// Meanwhile, a non-selectable placeholder node (see TreeNodeLoadingText) is
+// shown. Subsequent expansions reuse the loaded children until
+// InvalidateChildren is called. The node is collapsed.
+func (n *TreeNode) SetChildLoaderFunc(loader func(node *TreeNode) []*TreeNode) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.childLoader = loader
+	n.loaded = false
+	n.expanded = false
+}
+
+// SetChildrenLoadedFunc sets a handler which is called from the loader
+// goroutine when the child loader of this node has returned. The children are
+// attached the next time the tree view is drawn or GetChildren is called, so
+// the handler should redraw the application, e.g. by calling
+// Application.QueueUpdateDraw(func() {}).
+func (n *TreeNode) SetChildrenLoadedFunc(handler func()) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.childrenLoaded = handler
+}
+
+// InvalidateChildren marks the children loaded by the child loader as
+// outdated. They are loaded again immediately if the node is expanded, or
+// else the next time it is expanded.
+func (n *TreeNode) InvalidateChildren() {
+	n.Lock()
+	defer n.Unlock()
+
+	n.loaded = false
+	n.loadRequest++
+	n.loadedChildren = nil
+	n.childrenPending = false
+	if n.expanded {
+		n.loadChildren()
+	}
+}
+
+// loadChildren calls the child loader in a separate goroutine if the children
+// of this node have not been loaded yet. The caller must hold the lock.
+func (n *TreeNode) loadChildren() {
+	if n.childLoader == nil || n.loaded {
+		return
+	}
+	n.loaded = true
+	n.loadRequest++
+	request := n.loadRequest
+	loader := n.childLoader
+
+	placeholder := NewTreeNode(TreeNodeLoadingText)
+	placeholder.SetSelectable(false)
+	n.children = []*TreeNode{placeholder}
+	n.loadedChildren = nil
+	n.childrenPending = false
+
+	go func() {
+		children := loader(n)
+
+		n.Lock()
+		if request != n.loadRequest {
+			n.Unlock()
+			return
+		}
+		n.loadedChildren = children
+		n.childrenPending = true
+		loaded := n.childrenLoaded
+		n.Unlock()
+
+		if loaded != nil {
+			loaded()
+		}
+	}()
+}
+
+// attachLoadedChildren replaces the placeholder node with the children returned
+// by the child loader, if any. The caller must hold the lock.
+func (n *TreeNode) attachLoadedChildren() {
+	if !n.childrenPending {
+		return
+	}
+	n.children = n.loadedChildren
+	n.loadedChildren = nil
+	n.childrenPending = false
+	if n.checkable && n.checked {
+		for _, child := range n.children {
+			child.setChecked(true)
+		}
+	}
+}
+
 // SetExpanded sets whether or not this node's child nodes should be displayed.
 func (n *TreeNode) SetExpanded(expanded bool) {
 	n.Lock()
 	defer n.Unlock()
 
 	n.expanded = expanded
+	if expanded {
+		n.loadChildren()
+	}
 }
 
 // Expand makes the child nodes of this node appear.
@@ -250,6 +488,7 @@ func (n *TreeNode) Expand() {
 	defer n.Unlock()
 
 	n.expanded = true
+	n.loadChildren()
 }
 
 // Collapse makes the child nodes of this node disappear.
@@ -264,6 +503,7 @@ func (n *TreeNode) Collapse() {
 func (n *TreeNode) ExpandAll() {
 	n.Walk(func(node, parent *TreeNode, _ int) bool {
 		node.expanded = true
+		node.loadChildren()
 		return true
 	})
 }
@@ -373,6 +613,8 @@ func (n *TreeNode) GetIndent() int {
 //   - Ctrl-B, page up: Move (the selection) up by one page.
 //
 // Selected nodes can trigger the "selected" callback when the user hits Enter.
+// Pressing Space toggles the checkbox of checkable nodes (see
+// TreeNode.SetCheckable()).
 //
 // The root node corresponds to level 0, its children correspond to level 1,
 // their children to level 2, and so on. Per default, the first level that is
@@ -445,6 +687,16 @@ type TreeView struct {
 	// The visible nodes, top-down, as set by process().
 	nodes []*TreeNode
 
+	// Whether typing selects the next node whose text starts with the typed
+	// text.
+	typeAhead bool
+
+	// The text typed so far in type-ahead mode.
+	typeAheadText string
+
+	// The term of the last search.
+	searchTerm string
+
 	// Temporarily set to true while we know that the tree has not changed and
 	// therefore does not need to be reprocessed.
 	// TODO
@@ -498,6 +750,50 @@ func (t *TreeView) SetCurrentNode(node *TreeNode) {
 	}
 }
 
+// SetCurrentByPath focuses the node found at the provided path, as returned by
+// TreeNode.GetPath, expanding its parent nodes. Only nodes which are currently
+// loaded are searched. It returns false, leaving the selection unchanged, when
+// no such node exists.
+//
+// This function does NOT trigger the "changed" callback.
+func (t *TreeView) SetCurrentByPath(path []string) bool {
+	t.RLock()
+	node := t.root
+	t.RUnlock()
+	if node == nil || len(path) == 0 {
+		return false
+	}
+
+	node.RLock()
+	element := node.pathElement()
+	node.RUnlock()
+	if element != path[0] {
+		return false
+	}
+
+	var parents []*TreeNode
+PathLoop:
+	for _, element := range path[1:] {
+		for _, child := range node.GetChildren() {
+			child.RLock()
+			childElement := child.pathElement()
+			child.RUnlock()
+			if childElement == element {
+				parents = append(parents, node)
+				node = child
+				continue PathLoop
+			}
+		}
+		return false
+	}
+
+	for _, parent := range parents {
+		parent.SetExpanded(true)
+	}
+	t.SetCurrentNode(node)
+	return true
+}
+
 // GetCurrentNode returns the currently selected node or nil of no node is
 // currently selected.
 func (t *TreeView) GetCurrentNode() *TreeNode {
@@ -536,6 +832,27 @@ func (t *TreeView) GetPath(node *TreeNode) []*TreeNode {
 	return f(t.root, []*TreeNode{t.root})
 }
 
+// GetCheckedNodes returns all checkable nodes which are checked, in
+// depth-first, pre-order (NLR) order. This includes nodes which are not
+// visible.
+func (t *TreeView) GetCheckedNodes() []*TreeNode {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.root == nil {
+		return nil
+	}
+
+	var nodes []*TreeNode
+	t.root.walk(func(node, parent *TreeNode, _ int) bool {
+		if node.checkable && node.checkState() == treeChecked {
+			nodes = append(nodes, node)
+		}
+		return true
+	})
+	return nodes
+}
+
 // SetTopLevel sets the first tree level that is visible with 0 referring to the
 // root, 1 to the root's child nodes, and so on. Nodes above the top level are
 // not displayed.
@@ -676,6 +993,97 @@ func (t *TreeView) GetRowCount() int {
 	return len(t.nodes)
 }
 
+// SetTypeAhead sets a flag which determines whether typing selects the next
+// node whose text starts with the typed text (ignoring case). While typing,
+// letters are not used for navigation. The typed text is cleared when
+// pressing Escape or any other key, or when calling ClearSearch.
+func (t *TreeView) SetTypeAhead(typeAhead bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.typeAhead = typeAhead
+	t.typeAheadText = ""
+}
+
+// Search selects the next selectable node after the current node whose text
+// contains the provided term (ignoring case), wrapping around at the end of
+// the tree. The ancestors of the node are expanded and the node is scrolled
+// into view. The node is returned, or nil if no node matches.
+//
+// This function does NOT trigger the "changed" callback.
+func (t *TreeView) Search(term string) *TreeNode {
+	t.Lock()
+	defer t.Unlock()
+
+	t.searchTerm = term
+	lowerTerm := strings.ToLower(term)
+	return t.search(func(text string) bool {
+		return strings.Contains(strings.ToLower(text), lowerTerm)
+	}, false)
+}
+
+// GetSearch returns the term of the last search.
+func (t *TreeView) GetSearch() string {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.searchTerm
+}
+
+// ClearSearch clears the term of the last search and the text typed in
+// type-ahead mode.
+func (t *TreeView) ClearSearch() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.searchTerm = ""
+	t.typeAheadText = ""
+}
+
+// search selects the next node matching the provided function, starting after
+// the current node (or at the current node when includeCurrent is true). The
+// caller must hold the lock.
+func (t *TreeView) search(match func(text string) bool, includeCurrent bool) *TreeNode {
+	if t.root == nil {
+		return nil
+	}
+
+	// Collect all nodes, including those of collapsed nodes.
+	var nodes []*TreeNode
+	current := -1
+	t.root.walk(func(node, parent *TreeNode, level int) bool {
+		node.parent = parent
+		if level >= t.topLevel && node.selectable {
+			if node == t.currentNode {
+				current = len(nodes)
+			}
+			nodes = append(nodes, node)
+		}
+		return true
+	})
+
+	start := current + 1
+	if includeCurrent && current >= 0 {
+		start = current
+	}
+	for i := 0; i < len(nodes); i++ {
+		node := nodes[(start+i)%len(nodes)]
+		if !match(node.text) {
+			continue
+		}
+
+		// Expand the ancestors and scroll the node into view.
+		for parent := node.parent; parent != nil; parent = parent.parent {
+			parent.expanded = true
+		}
+		t.currentNode = node
+		t.movement = treeNone
+		t.process()
+		return node
+	}
+	return nil
+}
+
 // Transform modifies the current selection.
 func (t *TreeView) Transform(tr Transformation) {
 	t.Lock()
@@ -713,6 +1121,10 @@ func (t *TreeView) process() {
 		graphicsOffset = 1
 	}
 	t.root.walk(func(node, parent *TreeNode, _ int) bool {
+		node.Lock()
+		node.attachLoadedChildren()
+		node.Unlock()
+
 		// Set node attributes.
 		node.parent = parent
 		if parent == nil {
@@ -992,6 +1404,19 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 				_, prefixWidth = PrintStyle(screen, t.prefixes[(node.level-t.topLevel)%len(t.prefixes)], x+node.textX, posY, width-node.textX, AlignLeft, lineStyle.Foreground(node.color))
 			}
 
+			// Checkbox.
+			if node.checkable && node.textX+prefixWidth < width {
+				checkMark := ' '
+				switch node.checkState() {
+				case treeChecked:
+					checkMark = Styles.CheckBoxCheckedRune
+				case treeIndeterminate:
+					checkMark = Styles.CheckBoxIndeterminateRune
+				}
+				_, checkboxWidth := PrintStyle(screen, []byte(Escape(fmt.Sprintf("[%c] ", checkMark))), x+node.textX+prefixWidth, posY, width-node.textX-prefixWidth, AlignLeft, lineStyle.Foreground(node.color))
+				prefixWidth += checkboxWidth
+			}
+
 			// Text.
 			if node.textX+prefixWidth < width {
 				style := tcell.StyleDefault.Foreground(node.color).Bold(node.bold).Underline(node.underline)
@@ -1063,6 +1488,52 @@ func (t *TreeView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 		t.Lock()
 		defer t.Unlock()
 
+		// Toggle checkable nodes.
+		if HitShortcut(event, Keys.Select2) && t.currentNode != nil && t.currentNode.checkable && t.typeAheadText == "" {
+			node := t.currentNode
+			node.Lock()
+			node.setChecked(node.checkState() != treeChecked)
+			node.Unlock()
+			return
+		}
+
+		// Select nodes by typing.
+		if t.typeAhead {
+			previousNode := t.currentNode
+			typeAheadText := t.typeAheadText
+			switch event.Key() {
+			case tcell.KeyRune:
+				typeAheadText += string(event.Rune())
+			case tcell.KeyBackspace, tcell.KeyBackspace2:
+				if typeAheadText == "" {
+					return
+				}
+				r := []rune(typeAheadText)
+				typeAheadText = string(r[:len(r)-1])
+			case tcell.KeyEscape:
+				if typeAheadText != "" {
+					t.typeAheadText = ""
+					return
+				}
+			}
+			if typeAheadText != t.typeAheadText {
+				t.typeAheadText = typeAheadText
+				if typeAheadText != "" {
+					lowerText := strings.ToLower(typeAheadText)
+					t.search(func(text string) bool {
+						return strings.HasPrefix(strings.ToLower(text), lowerText)
+					}, true)
+				}
+				if t.currentNode != previousNode && t.changed != nil {
+					t.Unlock()
+					t.changed(t.currentNode)
+					t.Lock()
+				}
+				return
+			}
+			t.typeAheadText = ""
+		}
+
 		// Because the tree is flattened into a list only at drawing time, we also
 		// postpone the (selection) movement to drawing time.
 		if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
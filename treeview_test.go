@@ -1,7 +1,11 @@
 package cview
 
 import (
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -69,3 +73,279 @@ func TestTreeView(t *testing.T) {
 		t.Errorf("failed to initialize TreeView: incorrect row count: expected 1, got %d", tr.GetRowCount())
 	}
 }
+
+func TestTreeViewChildLoader(t *testing.T) {
+	t.Parallel()
+
+	var loads int
+	release := make(chan struct{})
+	node := NewTreeNode(treeViewTextA)
+	node.SetChildLoaderFunc(func(node *TreeNode) []*TreeNode {
+		<-release
+		loads++
+		return []*TreeNode{NewTreeNode(treeViewTextB)}
+	})
+	loaded := make(chan struct{})
+	node.SetChildrenLoadedFunc(func() {
+		loaded <- struct{}{}
+	})
+
+	if node.IsExpanded() {
+		t.Errorf("failed to collapse node with child loader")
+	}
+
+	node.Expand()
+	children := node.GetChildren()
+	if len(children) != 1 || children[0].GetText() != TreeNodeLoadingText {
+		t.Errorf("failed to show placeholder while loading children")
+	}
+	release <- struct{}{}
+	<-loaded
+
+	children = node.GetChildren()
+	if len(children) != 1 || children[0].GetText() != treeViewTextB {
+		t.Errorf("failed to load children")
+	}
+
+	// Loaded children are reused
+	node.Collapse()
+	node.Expand()
+	if loads != 1 || node.GetChildren()[0] != children[0] {
+		t.Errorf("failed to reuse loaded children: expected 1 load, got %d", loads)
+	}
+
+	// Invalidated children are loaded again
+	node.InvalidateChildren()
+	release <- struct{}{}
+	<-loaded
+	if loads != 2 || node.GetChildren()[0] == children[0] {
+		t.Errorf("failed to reload invalidated children: expected 2 loads, got %d", loads)
+	}
+}
+
+func TestTreeViewChildLoaderDraw(t *testing.T) {
+	t.Parallel()
+
+	root := NewTreeNode(treeViewTextA)
+	node := NewTreeNode(treeViewTextB)
+	root.AddChild(node)
+	tr := NewTreeView()
+	tr.SetRoot(root)
+
+	app, _, done := runTestApp(tr)
+	defer func() {
+		app.Stop()
+		<-done
+	}()
+
+	release := make(chan struct{})
+	node.SetChildLoaderFunc(func(node *TreeNode) []*TreeNode {
+		<-release
+		children := make([]*TreeNode, 3)
+		for i := range children {
+			children[i] = NewTreeNode(strconv.Itoa(i))
+		}
+		return children
+	})
+	loaded := make(chan struct{})
+	node.SetChildrenLoadedFunc(func() {
+		app.QueueUpdateDraw(func() {
+			close(loaded)
+		})
+	})
+	app.QueueUpdateSync(node.Expand)
+
+	// Draw while the children are loaded
+	stop := make(chan struct{})
+	drawing := make(chan struct{})
+	go func() {
+		defer close(drawing)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				app.QueueUpdateDraw(func() {})
+			}
+		}
+	}()
+	close(release)
+	<-loaded
+	close(stop)
+	<-drawing
+
+	var rows int
+	app.QueueUpdateSync(func() {
+		rows = tr.GetRowCount()
+	})
+	if rows != 5 {
+		t.Errorf("failed to attach loaded children: expected 5 rows, got %d", rows)
+	}
+}
+
+func TestTreeViewSearch(t *testing.T) {
+	t.Parallel()
+
+	root := NewTreeNode("root")
+	fruits := NewTreeNode("fruits")
+	apple := NewTreeNode("Apple")
+	banana := NewTreeNode("Banana")
+	fruits.AddChild(apple)
+	fruits.AddChild(banana)
+	vegetables := NewTreeNode("vegetables")
+	bean := NewTreeNode("Green bean")
+	vegetables.AddChild(bean)
+	root.AddChild(fruits)
+	root.AddChild(vegetables)
+	fruits.Collapse()
+	vegetables.Collapse()
+
+	tr := NewTreeView()
+	tr.SetRoot(root)
+	tr.SetCurrentNode(root)
+	tr.SetRect(0, 0, 20, 2)
+
+	if node := tr.Search("AN"); node != banana {
+		t.Fatalf("failed to search: expected Banana, got %v", node)
+	} else if !fruits.IsExpanded() {
+		t.Errorf("failed to expand ancestors of found node")
+	} else if tr.GetScrollOffset() != 2 {
+		t.Errorf("failed to scroll found node into view: expected offset 2, got %d", tr.GetScrollOffset())
+	}
+
+	if node := tr.Search("an"); node != bean {
+		t.Errorf("failed to search next node: expected Green bean, got %v", node)
+	}
+	if node := tr.Search("an"); node != banana {
+		t.Errorf("failed to wrap search: expected Banana, got %v", node)
+	}
+	if node := tr.Search("cherry"); node != nil || tr.GetCurrentNode() != banana {
+		t.Errorf("failed to search missing node: expected nil, got %v", node)
+	}
+
+	// Type-ahead
+	tr.SetTypeAhead(true)
+	inputHandler := tr.InputHandler()
+	for _, r := range "gr" {
+		inputHandler(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), nil)
+	}
+	if tr.GetCurrentNode() != bean {
+		t.Errorf("failed to select node by typing: expected Green bean, got %s", tr.GetCurrentNode().GetText())
+	}
+	tr.ClearSearch()
+	inputHandler(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone), nil)
+	if tr.GetCurrentNode() != apple {
+		t.Errorf("failed to clear type-ahead text: expected Apple, got %s", tr.GetCurrentNode().GetText())
+	}
+}
+
+func TestTreeViewCheckable(t *testing.T) {
+	t.Parallel()
+
+	root := NewTreeNode("root")
+	a := NewTreeNode("a")
+	b := NewTreeNode("b")
+	c := NewTreeNode("c")
+	for _, node := range []*TreeNode{root, a, b, c} {
+		node.SetCheckable(true)
+	}
+	root.AddChild(a)
+	a.AddChild(b)
+	a.AddChild(c)
+
+	tr := NewTreeView()
+	tr.SetRoot(root)
+	tr.SetCurrentNode(b)
+
+	app, err := newTestApp(tr)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	tr.SetRect(0, 0, 20, 4)
+
+	tr.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), nil)
+	if !b.IsChecked() || c.IsChecked() {
+		t.Errorf("failed to toggle checkable node")
+	} else if a.IsChecked() || root.IsChecked() {
+		t.Errorf("failed to determine check state of parent nodes: expected unchecked")
+	}
+
+	tr.Draw(app.screen)
+	for y, expected := range []rune{Styles.CheckBoxIndeterminateRune, Styles.CheckBoxIndeterminateRune, Styles.CheckBoxCheckedRune, ' '} {
+		found := false
+		for x := 0; x < 20; x++ {
+			if r, _, _, _ := app.screen.GetContent(x, y); r == '[' {
+				if r, _, _, _ := app.screen.GetContent(x+1, y); r != expected {
+					t.Errorf("failed to draw checkbox on row %d: expected %c, got %c", y, expected, r)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("failed to draw checkbox on row %d", y)
+		}
+	}
+
+	a.SetChecked(true)
+	if !root.IsChecked() {
+		t.Errorf("failed to check descendants")
+	}
+	checked := tr.GetCheckedNodes()
+	if len(checked) != 4 {
+		t.Errorf("failed to get checked nodes: expected 4 nodes, got %d", len(checked))
+	}
+}
+
+func TestTreeViewPath(t *testing.T) {
+	t.Parallel()
+
+	build := func(reference interface{}) (*TreeView, *TreeNode) {
+		root := NewTreeNode("Root")
+		folder := NewTreeNode("Folder")
+		folder.SetReference(reference)
+		leaf := NewTreeNode("Leaf")
+		folder.AddChild(leaf)
+		root.AddChild(NewTreeNode("Other"))
+		root.AddChild(folder)
+
+		tr := NewTreeView()
+		tr.SetRoot(root)
+		tr.SetCurrentNode(root)
+		return tr, leaf
+	}
+
+	tr, leaf := build(nil)
+	app, err := newTestApp(tr)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	tr.Draw(app.screen)
+
+	path := leaf.GetPath()
+	if strings.Join(path, "/") != "Root/Folder/Leaf" {
+		t.Errorf("failed to get TreeNode path: expected Root/Folder/Leaf, got %v", path)
+	}
+
+	// Restore the selection in a rebuilt tree.
+	tr, leaf = build(nil)
+	tr.GetRoot().GetChildren()[1].Collapse()
+	if !tr.SetCurrentByPath(path) {
+		t.Error("failed to set current TreeView node by path")
+	} else if tr.GetCurrentNode() != leaf {
+		t.Errorf("failed to set current TreeView node by path: got %s", tr.GetCurrentNode().GetText())
+	} else if !tr.GetRoot().GetChildren()[1].IsExpanded() {
+		t.Error("failed to expand parent TreeView nodes")
+	}
+
+	// References are preferred over texts.
+	tr, _ = build("folder-id")
+	if tr.SetCurrentByPath(path) {
+		t.Error("failed to match TreeView node references: matched text")
+	} else if tr.GetCurrentNode() != tr.GetRoot() {
+		t.Error("failed to keep current TreeView node")
+	}
+	if !tr.SetCurrentByPath([]string{"Root", "folder-id", "Leaf"}) {
+		t.Error("failed to match TreeView node references")
+	}
+}
@@ -247,6 +247,23 @@ func overlayStyle(background tcell.Color, defaultStyle tcell.Style, fgColor, bgC
 	return style
 }
 
+// composeStyle applies the colors and attributes set in the overlay style to
+// the provided style. Colors which are not set in the overlay are preserved.
+func composeStyle(style tcell.Style, overlay tcell.Style) tcell.Style {
+	fg, bg, attrs := overlay.Decompose()
+	if fg != tcell.ColorDefault {
+		style = style.Foreground(fg)
+	}
+	if bg != tcell.ColorDefault {
+		style = style.Background(bg)
+	}
+	if attrs != 0 {
+		_, _, existing := style.Decompose()
+		style = style.Attributes(existing | attrs)
+	}
+	return style
+}
+
 // SetAttributes sets attributes on a style.
 func SetAttributes(style tcell.Style, attrs tcell.AttrMask) tcell.Style {
 	return style.
@@ -730,6 +747,22 @@ func iterateStringReverse(text string, callback func(main rune, comb []rune, tex
 	return false
 }
 
+// DropDownOpenDirection specifies the direction in which the list of options
+// of a DropDown opens.
+type DropDownOpenDirection int
+
+const (
+	// DropDownOpenAuto opens the list below the field, unless there is not
+	// enough room below it and more room above it.
+	DropDownOpenAuto DropDownOpenDirection = iota
+
+	// DropDownOpenDown always opens the list below the field.
+	DropDownOpenDown
+
+	// DropDownOpenUp always opens the list above the field.
+	DropDownOpenUp
+)
+
 // ScrollBarVisibility specifies the display of a scroll bar.
 type ScrollBarVisibility int
 
@@ -17,3 +17,22 @@ func newTestApp(root Primitive) (*Application, error) {
 
 	return app, nil
 }
+
+// runTestApp runs an application on a simulation screen. The returned channel
+// receives the error returned by Run.
+func runTestApp(root Primitive) (*Application, tcell.SimulationScreen, <-chan error) {
+	sc := tcell.NewSimulationScreen("UTF-8")
+	sc.SetSize(80, 24)
+
+	app := NewApplication()
+	app.SetScreen(sc)
+	app.SetRoot(root, true)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run()
+	}()
+	app.QueueUpdateSync(func() {}) // Wait for the application to run.
+
+	return app, sc, done
+}
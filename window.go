@@ -7,7 +7,9 @@ import (
 )
 
 // Window is a draggable, resizable frame around a primitive. Windows must be
-// added to a WindowManager.
+// added to a WindowManager. Windows may be maximized to cover the entire
+// manager (see SetFullscreen) and minimized to a title bar at the bottom of
+// the manager (see SetMinimized).
 type Window struct {
 	*Box
 
@@ -15,9 +17,19 @@ type Window struct {
 
 	fullscreen bool
 
+	// Whether or not the window may be resized by dragging its edges, and the
+	// minimum size of the window when resizing.
+	resizable           bool
+	minWidth, minHeight int
+
 	normalX, normalY int
 	normalW, normalH int
 
+	// Whether or not the window is minimized, and the position of the window
+	// before it was minimized.
+	minimized                              bool
+	restoreX, restoreY, restoreW, restoreH int
+
 	dragX, dragY   int
 	dragWX, dragWY int
 
@@ -31,6 +43,9 @@ func NewWindow(primitive Primitive) *Window {
 		primitive: primitive,
 		dragWX:    -1,
 		dragWY:    -1,
+		resizable: true,
+		minWidth:  Styles.WindowMinWidth,
+		minHeight: Styles.WindowMinHeight,
 	}
 	w.Box.focus = w
 	return w
@@ -54,24 +69,94 @@ func (w *Window) SetFullscreen(fullscreen bool) {
 	}
 }
 
-// Focus is called when this primitive receives focus.
-func (w *Window) Focus(delegate func(p Primitive)) {
+// GetFullscreen returns whether or not the window is drawn fullscreen.
+func (w *Window) GetFullscreen() bool {
+	w.RLock()
+	defer w.RUnlock()
+
+	return w.fullscreen
+}
+
+// SetMinimized sets the flag indicating whether or not the window is
+// minimized. Minimized windows are drawn as a title bar at the bottom of the
+// window manager and do not receive focus. Clicking the title bar restores
+// the window to its previous position.
+func (w *Window) SetMinimized(minimized bool) {
 	w.Lock()
 	defer w.Unlock()
 
+	if w.minimized == minimized {
+		return
+	}
+
+	w.minimized = minimized
+	if w.minimized {
+		w.restoreX, w.restoreY, w.restoreW, w.restoreH = w.GetRect()
+	} else {
+		w.SetRect(w.restoreX, w.restoreY, w.restoreW, w.restoreH)
+	}
+}
+
+// GetMinimized returns whether or not the window is minimized.
+func (w *Window) GetMinimized() bool {
+	w.RLock()
+	defer w.RUnlock()
+
+	return w.minimized
+}
+
+// minimizedWidth returns the width of the title bar of the minimized window.
+func (w *Window) minimizedWidth() int {
+	return TaggedStringWidth(w.GetTitle()) + 4
+}
+
+// SetResizable sets whether or not the window may be resized by dragging its
+// edges with the mouse. Windows are resizable by default.
+func (w *Window) SetResizable(resizable bool) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.resizable = resizable
+}
+
+// GetResizable returns whether or not the window may be resized by dragging
+// its edges with the mouse.
+func (w *Window) GetResizable() bool {
+	w.RLock()
+	defer w.RUnlock()
+
+	return w.resizable
+}
+
+// SetMinSize sets the minimum size of the window when it is resized with the
+// mouse.
+func (w *Window) SetMinSize(width, height int) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.minWidth, w.minHeight = width, height
+}
+
+// Focus is called when this primitive receives focus.
+func (w *Window) Focus(delegate func(p Primitive)) {
+	w.RLock()
+	primitive := w.primitive
+	w.RUnlock()
+
 	w.Box.Focus(delegate)
 
-	w.primitive.Focus(delegate)
+	primitive.Focus(delegate)
 }
 
 // Blur is called when this primitive loses focus.
 func (w *Window) Blur() {
-	w.Lock()
-	defer w.Unlock()
+	w.RLock()
+	primitive := w.primitive
+	w.RUnlock()
 
 	w.Box.Blur()
 
-	w.primitive.Blur()
+	primitive.Blur()
 }
 
 // HasFocus returns whether or not this primitive has focus.
@@ -96,6 +181,11 @@ func (w *Window) Draw(screen tcell.Screen) {
 	w.RLock()
 	defer w.RUnlock()
 
+	if w.minimized {
+		w.drawMinimized(screen)
+		return
+	}
+
 	w.Box.Draw(screen)
 
 	x, y, width, height := w.GetInnerRect()
@@ -103,6 +193,37 @@ func (w *Window) Draw(screen tcell.Screen) {
 	w.primitive.Draw(screen)
 }
 
+// drawMinimized draws the title bar of the minimized window. The caller must
+// hold the lock.
+func (w *Window) drawMinimized(screen tcell.Screen) {
+	x, y, width, _ := w.GetRect()
+	if width <= 0 {
+		return
+	}
+
+	w.Box.l.RLock()
+	title := w.title
+	titleColor := w.titleColor
+	style := tcell.StyleDefault.Foreground(w.borderColor).Background(w.backgroundColor)
+	w.Box.l.RUnlock()
+
+	for index := 0; index < width; index++ {
+		screen.SetContent(x+index, y, Borders.Horizontal, nil, style)
+	}
+	screen.SetContent(x, y, '[', nil, style)
+	screen.SetContent(x+width-1, y, ']', nil, style)
+	Print(screen, title, x+2, y, width-4, AlignCenter, titleColor)
+}
+
+// interceptKey passes a key event on to the contained primitive.
+func (w *Window) interceptKey(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	w.RLock()
+	primitive := w.primitive
+	w.RUnlock()
+
+	return interceptKey(event, setFocus, primitive)
+}
+
 // InputHandler returns the handler for this primitive.
 func (w *Window) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return w.primitive.InputHandler()
@@ -128,6 +249,13 @@ func (w *Window) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 			bottomEdge := mouseY == y+height-1
 			topEdge := mouseY == y
 
+			w.RLock()
+			resizable := w.resizable
+			w.RUnlock()
+			if !resizable {
+				leftEdge, rightEdge, bottomEdge = false, false, false
+			}
+
 			if mouseY >= y && mouseY <= y+height-1 {
 				if leftEdge {
 					w.dragX = -1
@@ -47,11 +47,12 @@ func (wm *WindowManager) Focus(delegate func(p Primitive)) {
 	wm.Lock()
 	defer wm.Unlock()
 
-	if len(wm.windows) == 0 {
-		return
+	for index := len(wm.windows) - 1; index >= 0; index-- {
+		if w := wm.windows[index]; !w.minimized {
+			w.Focus(delegate)
+			return
+		}
 	}
-
-	wm.windows[len(wm.windows)-1].Focus(delegate)
 }
 
 // HasFocus returns whether or not this primitive has focus.
@@ -68,6 +69,18 @@ func (wm *WindowManager) HasFocus() bool {
 	return false
 }
 
+// interceptKey passes a key event on to the focused window.
+func (wm *WindowManager) interceptKey(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	wm.RLock()
+	items := make([]Primitive, len(wm.windows))
+	for i, w := range wm.windows {
+		items[i] = w
+	}
+	wm.RUnlock()
+
+	return interceptKey(event, setFocus, items...)
+}
+
 // Draw draws this primitive onto the screen.
 func (wm *WindowManager) Draw(screen tcell.Screen) {
 	if !wm.GetVisible() {
@@ -83,7 +96,7 @@ func (wm *WindowManager) Draw(screen tcell.Screen) {
 
 	var hasFullScreen bool
 	for _, w := range wm.windows {
-		if !w.fullscreen || !w.GetVisible() {
+		if !w.fullscreen || w.minimized || !w.GetVisible() {
 			continue
 		}
 
@@ -92,12 +105,35 @@ func (wm *WindowManager) Draw(screen tcell.Screen) {
 
 		w.Draw(screen)
 	}
-	if hasFullScreen {
-		return
+	if !hasFullScreen {
+		wm.drawWindows(screen, x, y, width, height)
 	}
 
+	// Draw the title bars of minimized windows in rows from the bottom up.
+	barX, barY := x, y+height-1
 	for _, w := range wm.windows {
-		if !w.GetVisible() {
+		if !w.minimized || !w.GetVisible() {
+			continue
+		}
+
+		barWidth := w.minimizedWidth()
+		if barWidth > width {
+			barWidth = width
+		}
+		if barX > x && barX+barWidth > x+width {
+			barX, barY = x, barY-1
+		}
+		w.SetRect(barX, barY, barWidth, 1)
+		w.Draw(screen)
+		barX += barWidth + 1
+	}
+}
+
+// drawWindows draws the windows which are not minimized. The caller must hold
+// the lock.
+func (wm *WindowManager) drawWindows(screen tcell.Screen, x, y, width, height int) {
+	for _, w := range wm.windows {
+		if w.minimized || !w.GetVisible() {
 			continue
 		}
 
@@ -125,16 +161,40 @@ func (wm *WindowManager) Draw(screen tcell.Screen) {
 	}
 }
 
+// dragging returns whether or not a window is being moved or resized.
+func (wm *WindowManager) dragging() bool {
+	for _, w := range wm.windows {
+		if w.dragX != 0 || w.dragY != 0 || w.dragWX != -1 || w.dragWY != -1 {
+			return true
+		}
+	}
+	return false
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (wm *WindowManager) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return wm.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
-		if !wm.InRect(event.Position()) {
+		if !wm.InRect(event.Position()) && !wm.dragging() {
 			return false, nil
 		}
 
 		if action == MouseMove {
 			mouseX, mouseY := event.Position()
 
+			// Windows are not resized beyond the bounds of the manager.
+			x, y, width, height := wm.GetInnerRect()
+			resizeX, resizeY := mouseX, mouseY
+			if resizeX < x {
+				resizeX = x
+			} else if resizeX > x+width-1 {
+				resizeX = x + width - 1
+			}
+			if resizeY < y {
+				resizeY = y
+			} else if resizeY > y+height-1 {
+				resizeY = y + height - 1
+			}
+
 			for _, w := range wm.windows {
 				if w.dragWX != -1 || w.dragWY != -1 {
 					offsetX := w.x - mouseX
@@ -147,18 +207,20 @@ func (wm *WindowManager) MouseHandler() func(action MouseAction, event *tcell.Ev
 					consumed = true
 				}
 
+				mouseX, mouseY := resizeX, resizeY
+
 				if w.dragX != 0 {
 					if w.dragX == -1 {
 						offsetX := w.x - mouseX
 
-						if w.width+offsetX >= Styles.WindowMinWidth {
+						if w.width+offsetX >= w.minWidth {
 							w.x -= offsetX
 							w.width += offsetX
 						}
 					} else {
 						offsetX := mouseX - (w.x + w.width) + 1
 
-						if w.width+offsetX >= Styles.WindowMinWidth {
+						if w.width+offsetX >= w.minWidth {
 							w.width += offsetX
 						}
 					}
@@ -171,13 +233,13 @@ func (wm *WindowManager) MouseHandler() func(action MouseAction, event *tcell.Ev
 					if w.dragY == -1 {
 						offsetY := mouseY - (w.y + w.height) + 1
 
-						if w.height+offsetY >= Styles.WindowMinHeight {
+						if w.height+offsetY >= w.minHeight {
 							w.height += offsetY
 						}
 					} else {
 						offsetY := w.y - mouseY
 
-						if w.height+offsetY >= Styles.WindowMinHeight {
+						if w.height+offsetY >= w.minHeight {
 							w.y -= offsetY
 							w.height += offsetY
 						}
@@ -217,6 +279,15 @@ func (wm *WindowManager) MouseHandler() func(action MouseAction, event *tcell.Ev
 				wm.windows = append(append(wm.windows[:focusWindowIndex], wm.windows[focusWindowIndex+1:]...), focusWindow)
 			}
 
+			// Clicking the title bar of a minimized window restores it.
+			if focusWindow.GetMinimized() {
+				if action == MouseLeftClick {
+					focusWindow.SetMinimized(false)
+					setFocus(focusWindow)
+				}
+				return true, nil
+			}
+
 			return focusWindow.MouseHandler()(action, event, setFocus)
 		}
 
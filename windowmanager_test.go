@@ -0,0 +1,121 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestWindowManagerResize(t *testing.T) {
+	t.Parallel()
+
+	w := NewWindow(NewBox())
+	w.SetRect(5, 5, 20, 10)
+	w.SetMinSize(10, 5)
+
+	wm := NewWindowManager()
+	wm.Add(w)
+	wm.SetRect(0, 0, 40, 20)
+
+	handler := wm.MouseHandler()
+	mouse := func(action MouseAction, x, y int) {
+		handler(action, tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {})
+	}
+	drag := func(fromX, fromY, toX, toY int) {
+		mouse(MouseLeftDown, fromX, fromY)
+		mouse(MouseMove, toX, toY)
+		mouse(MouseLeftUp, toX, toY)
+	}
+
+	// Drag the bottom-right corner.
+	drag(24, 14, 29, 16)
+	if x, y, width, height := w.GetRect(); x != 5 || y != 5 || width != 25 || height != 12 {
+		t.Errorf("failed to resize Window: expected 5,5 25x12, got %d,%d %dx%d", x, y, width, height)
+	}
+
+	// Resizing is clamped to the bounds of the manager.
+	drag(29, 16, 60, 30)
+	if _, _, width, height := w.GetRect(); width != 35 || height != 15 {
+		t.Errorf("failed to clamp Window size to manager: expected 35x15, got %dx%d", width, height)
+	}
+
+	// Resizing is clamped to the minimum size.
+	drag(5, 10, 38, 10)
+	if x, _, width, _ := w.GetRect(); x != 5 || width != 35 {
+		t.Errorf("failed to clamp Window to minimum size: expected x 5 width 35, got x %d width %d", x, width)
+	}
+
+	// Non-resizable windows are not resized.
+	w.SetResizable(false)
+	drag(39, 10, 30, 10)
+	if _, _, width, _ := w.GetRect(); width != 35 {
+		t.Errorf("failed to prevent resizing Window: expected width 35, got %d", width)
+	}
+}
+
+func TestWindowManagerMinimize(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewWindow(NewBox()), NewWindow(NewBox())
+	a.SetTitle("A")
+	b.SetTitle("B")
+	a.SetRect(5, 5, 20, 10)
+	b.SetRect(10, 2, 20, 10)
+
+	wm := NewWindowManager()
+	wm.Add(a, b)
+
+	app, err := newTestApp(wm)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	wm.SetRect(0, 0, 40, 20)
+
+	a.SetMinimized(true)
+	b.SetMinimized(true)
+	wm.Draw(app.screen)
+	if x, y, width, height := a.GetRect(); x != 0 || y != 19 || width != 5 || height != 1 {
+		t.Errorf("failed to draw minimized Window: expected 0,19 5x1, got %d,%d %dx%d", x, y, width, height)
+	} else if x, y, _, _ := b.GetRect(); x != 6 || y != 19 {
+		t.Errorf("failed to draw minimized Window: expected 6,19, got %d,%d", x, y)
+	}
+	if r, _, _, _ := app.screen.GetContent(2, 19); r != 'A' {
+		t.Errorf("failed to draw title of minimized Window: got %c", r)
+	}
+
+	// Clicking the title bar restores the window.
+	var focused Primitive
+	handler := wm.MouseHandler()
+	for _, action := range []MouseAction{MouseLeftDown, MouseLeftUp, MouseLeftClick} {
+		handler(action, tcell.NewEventMouse(1, 19, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {
+			focused = p
+		})
+	}
+	if a.GetMinimized() || focused != a {
+		t.Fatalf("failed to restore minimized Window")
+	} else if x, y, width, height := a.GetRect(); x != 5 || y != 5 || width != 20 || height != 10 {
+		t.Errorf("failed to restore Window position: expected 5,5 20x10, got %d,%d %dx%d", x, y, width, height)
+	}
+}
+
+func TestWindowFocusFunc(t *testing.T) {
+	t.Parallel()
+
+	w := NewWindow(NewBox())
+	w.SetFocusFunc(func() {
+		w.SetFullscreen(true)
+	})
+	w.SetBlurFunc(func() {
+		w.SetMinimized(true)
+		w.SetResizable(false)
+	})
+
+	w.Focus(func(p Primitive) {})
+	if !w.GetFullscreen() {
+		t.Errorf("failed to modify Window when focused")
+	}
+	w.Blur()
+	if !w.GetMinimized() || w.GetResizable() {
+		t.Errorf("failed to modify Window when blurred")
+	}
+}